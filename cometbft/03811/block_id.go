@@ -0,0 +1,72 @@
+package v03811
+
+import (
+	"bytes"
+	"fmt"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"go.uber.org/zap"
+)
+
+type BlockIDMismatchPolicy string
+
+const (
+	// BlockIDMismatchPolicyUseReported emits the BlockID returned by the endpoint or stored in the
+	// node block meta, which is the one the chain refers to in the next block `LastBlockID`. It is
+	// the default, blocks were always emitted with the reported BlockID.
+	BlockIDMismatchPolicyUseReported BlockIDMismatchPolicy = "use-reported"
+	// BlockIDMismatchPolicyFail refuses to emit a block whose locally computed hash differs from
+	// the BlockID returned by the endpoint or stored in the node block meta
+	BlockIDMismatchPolicyFail BlockIDMismatchPolicy = "fail"
+)
+
+func ParseBlockIDMismatchPolicy(in string) (BlockIDMismatchPolicy, error) {
+	switch policy := BlockIDMismatchPolicy(in); policy {
+	case BlockIDMismatchPolicyUseReported, BlockIDMismatchPolicyFail:
+		return policy, nil
+	}
+	return "", fmt.Errorf("invalid block id mismatch policy %q, expected %q or %q", in, BlockIDMismatchPolicyUseReported, BlockIDMismatchPolicyFail)
+}
+
+// checkBlockID compares the hash of the header computed locally with the BlockID returned by
+// the endpoint (or stored in the block meta of a node data directory) and returns the hash to
+// emit, source is where the block was read from. Forks hashing the header differently (such as
+// Injective's patched cometbft) make them diverge, every header field is logged in that case to
+// find out which one is hashed differently.
+func checkBlockID(rpcBlock *ctypes.ResultBlock, policy BlockIDMismatchPolicy, source string, logger *zap.Logger) ([]byte, error) {
+	computedHash := rpcBlock.Block.Hash()
+	if bytes.Equal(computedHash, rpcBlock.BlockID.Hash) {
+		return computedHash, nil
+	}
+
+	blockIDMismatches.Inc()
+	header := rpcBlock.Block.Header
+	logger.Warn("block hash computed from header differs from the served block id",
+		zap.Int64("block_num", header.Height),
+		zap.String("source", source),
+		zap.Stringer("block_id_hash", rpcBlock.BlockID.Hash),
+		zap.Stringer("computed_hash", computedHash),
+		zap.String("policy", string(policy)),
+		zap.Uint64("header_version_block", header.Version.Block),
+		zap.Uint64("header_version_app", header.Version.App),
+		zap.String("header_chain_id", header.ChainID),
+		zap.Time("header_time", header.Time),
+		zap.Stringer("header_last_block_id_hash", header.LastBlockID.Hash),
+		zap.Uint32("header_last_block_id_part_set_total", header.LastBlockID.PartSetHeader.Total),
+		zap.Stringer("header_last_block_id_part_set_hash", header.LastBlockID.PartSetHeader.Hash),
+		zap.Stringer("header_last_commit_hash", header.LastCommitHash),
+		zap.Stringer("header_data_hash", header.DataHash),
+		zap.Stringer("header_validators_hash", header.ValidatorsHash),
+		zap.Stringer("header_next_validators_hash", header.NextValidatorsHash),
+		zap.Stringer("header_consensus_hash", header.ConsensusHash),
+		zap.Stringer("header_app_hash", header.AppHash),
+		zap.Stringer("header_last_results_hash", header.LastResultsHash),
+		zap.Stringer("header_evidence_hash", header.EvidenceHash),
+		zap.Stringer("header_proposer_address", header.ProposerAddress),
+	)
+
+	if policy != BlockIDMismatchPolicyFail {
+		return rpcBlock.BlockID.Hash, nil
+	}
+	return nil, fmt.Errorf("block %d hash computed from header %s differs from block id %s served by %q", header.Height, computedHash, rpcBlock.BlockID.Hash, source)
+}
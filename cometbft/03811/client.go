@@ -0,0 +1,161 @@
+package v03811
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	cometBftHttp "github.com/cometbft/cometbft/rpc/client/http"
+	jsonrpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	"go.uber.org/zap"
+)
+
+// maxConsecutiveFailures is the number of failed fetches in a row after which an endpoint is
+// considered unhealthy until it serves a block again.
+const maxConsecutiveFailures = 3
+
+type CometHttpClientWrap struct {
+	// endpoint is redacted, it is safe to log
+	endpoint string
+	// rawEndpoint is the endpoint as configured, it must not be logged
+	rawEndpoint     string
+	tier            EndpointTier
+	cometHttpClient *cometBftHttp.HTTP
+	batchClient     *rpcBatchClient
+	guard           *endpointGuard
+
+	consecutiveFailures atomic.Int64
+	stats               clientStats
+
+	// unix nanoseconds until which the endpoint is not used, after serving an inconsistent block
+	quarantinedUntil atomic.Int64
+
+	// block range held by the endpoint as reported by `/status`, 0 until first fetched. The latest
+	// height is also raised by the heads and blocks the endpoint serves in between.
+	earliestBlockHeight atomic.Int64
+	latestBlockHeight   atomic.Int64
+	// whether the last `/status` refresh failed
+	statusFailed atomic.Bool
+}
+
+// NewCometHttpClientWrap creates the rpc client of an endpoint, every request it sends goes
+// through the endpoint rate limit and circuit breaker. auth is optional.
+func NewCometHttpClientWrap(endpoint string, tier EndpointTier, limits EndpointLimits, auth *EndpointAuth, logger *zap.Logger) (*CometHttpClientWrap, error) {
+	redactedEndpoint := RedactEndpoint(endpoint)
+
+	httpClient, err := jsonrpcclient.DefaultHTTPClient(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("creating http client: %w", err)
+	}
+
+	transport := httpClient.Transport
+	if auth != nil {
+		baseTransport, ok := transport.(*http.Transport)
+		if !ok {
+			return nil, fmt.Errorf("unexpected http transport %T", transport)
+		}
+		if transport, err = newAuthTransport(auth, baseTransport); err != nil {
+			return nil, fmt.Errorf("configuring auth: %w", err)
+		}
+	}
+
+	guard := newEndpointGuard(redactedEndpoint, limits, transport, logger)
+	httpClient.Transport = guard
+
+	cometHttpClient, err := cometBftHttp.NewWithClient(endpoint, "", httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("creating rpc client: %w", err)
+	}
+
+	batchClient, err := newRPCBatchClient(endpoint, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("creating rpc batch client: %w", err)
+	}
+
+	return &CometHttpClientWrap{
+		endpoint:        redactedEndpoint,
+		rawEndpoint:     endpoint,
+		tier:            tier,
+		cometHttpClient: cometHttpClient,
+		batchClient:     batchClient,
+		guard:           guard,
+	}, nil
+}
+
+// redactError redacts the endpoint in the errors returned by the rpc clients.
+func (c *CometHttpClientWrap) redactError(err error) error {
+	return redactEndpointError(err, c.rawEndpoint)
+}
+
+func (c *CometHttpClientWrap) recordSuccess() {
+	c.consecutiveFailures.Store(0)
+	c.stats.recordOutcome(false)
+}
+
+func (c *CometHttpClientWrap) recordFailure() {
+	c.consecutiveFailures.Add(1)
+	c.stats.recordOutcome(true)
+}
+
+// Healthy tells if the endpoint did not fail its last fetches in a row, its circuit breaker is
+// not open, it did not ask to retry later and it is not quarantined.
+func (c *CometHttpClientWrap) Healthy() bool {
+	return c.consecutiveFailures.Load() < maxConsecutiveFailures && !c.guard.Open() && !c.guard.Paused() && !c.Quarantined()
+}
+
+// Quarantine stops using the endpoint for the given duration.
+func (c *CometHttpClientWrap) Quarantine(duration time.Duration) {
+	c.quarantinedUntil.Store(time.Now().Add(duration).UnixNano())
+}
+
+func (c *CometHttpClientWrap) Quarantined() bool {
+	return time.Now().UnixNano() < c.quarantinedUntil.Load()
+}
+
+// UpdateStatus refreshes the block range held by the endpoint from its `/status`.
+func (c *CometHttpClientWrap) UpdateStatus(ctx context.Context) error {
+	status, err := c.cometHttpClient.Status(ctx)
+	err = c.redactError(err)
+	c.statusFailed.Store(err != nil)
+	if err != nil {
+		return fmt.Errorf("fetching status: %w", err)
+	}
+
+	c.earliestBlockHeight.Store(status.SyncInfo.EarliestBlockHeight)
+	c.latestBlockHeight.Store(status.SyncInfo.LatestBlockHeight)
+	return nil
+}
+
+// BlockRange returns the earliest and latest heights last reported by the endpoint.
+func (c *CometHttpClientWrap) BlockRange() (earliest int64, latest int64) {
+	return c.earliestBlockHeight.Load(), c.latestBlockHeight.Load()
+}
+
+// HoldsBlock tells if the block is within the range last known to be held by the endpoint. An
+// endpoint whose status was never fetched is not known to hold any block.
+func (c *CometHttpClientWrap) HoldsBlock(blockNum uint64) bool {
+	earliest, latest := c.BlockRange()
+	return latest > 0 && uint64(earliest) <= blockNum && blockNum <= uint64(latest)
+}
+
+// observeLatest raises the latest height held by the endpoint after it served a head or a block
+// above the one its last `/status` reported.
+func (c *CometHttpClientWrap) observeLatest(height int64) {
+	for {
+		latest := c.latestBlockHeight.Load()
+		if height <= latest || c.latestBlockHeight.CompareAndSwap(latest, height) {
+			return
+		}
+	}
+}
+
+// refreshStatusFor refreshes the block range of the endpoint when it is not known to hold the
+// block yet, the periodic refresh is too old to follow the head.
+func (c *CometHttpClientWrap) refreshStatusFor(ctx context.Context, blockNum uint64) error {
+	if _, latest := c.BlockRange(); latest > 0 && blockNum <= uint64(latest) {
+		return nil
+	}
+	return c.UpdateStatus(ctx)
+}
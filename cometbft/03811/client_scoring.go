@@ -0,0 +1,106 @@
+package v03811
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// statsDecay is the weight given to the newest sample in the moving averages of clientStats.
+const statsDecay = 0.2
+
+// clientStats keeps exponentially weighted moving averages of the latency and error rate of the
+// requests sent to an endpoint.
+type clientStats struct {
+	lock      sync.Mutex
+	latencyMs float64
+	errorRate float64
+	sampled   bool
+}
+
+func (s *clientStats) recordLatency(d time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ms := float64(d.Milliseconds())
+	if !s.sampled {
+		s.latencyMs = ms
+		s.sampled = true
+		return
+	}
+	s.latencyMs = statsDecay*ms + (1-statsDecay)*s.latencyMs
+}
+
+func (s *clientStats) recordOutcome(failed bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	sample := 0.0
+	if failed {
+		sample = 1.0
+	}
+	s.errorRate = statsDecay*sample + (1-statsDecay)*s.errorRate
+}
+
+func (s *clientStats) snapshot() (latencyMs float64, errorRate float64, sampled bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.latencyMs, s.errorRate, s.sampled
+}
+
+// ClientScorer scores endpoints so the rpc clients can be sorted best first, it is meant to be
+// used with `rpc.Clients.StartSorting` in ascending order along with a rolling strategy that
+// always starts with the first client. The score is a cost: the endpoint's average latency,
+// inflated by its error rate, plus a penalty for each block it lags behind the most advanced
+// endpoint. Endpoint block ranges come from the head tracker status refreshes.
+type ClientScorer struct {
+	headTracker            *HeadTracker
+	blockLagPenalty        time.Duration
+	failedUnsampledLatency time.Duration
+	errorRateModifier      float64
+	logger                 *zap.Logger
+}
+
+func NewClientScorer(headTracker *HeadTracker, blockLagPenalty time.Duration, logger *zap.Logger) *ClientScorer {
+	return &ClientScorer{
+		headTracker:            headTracker,
+		blockLagPenalty:        blockLagPenalty,
+		failedUnsampledLatency: time.Second,
+		errorRateModifier:      10,
+		logger:                 logger,
+	}
+}
+
+// FetchSortValue implements firecore's rpc.SortValueFetcher. Endpoints whose last status refresh
+// failed get the worst score.
+func (s *ClientScorer) FetchSortValue(_ context.Context, client *CometHttpClientWrap) (uint64, error) {
+	if client.statusFailed.Load() {
+		s.logger.Debug("scored endpoint whose status refresh failed", zap.String("rpc_endpoint", client.endpoint))
+		return math.MaxUint64, nil
+	}
+
+	score := s.score(client)
+	s.logger.Debug("scored endpoint", zap.String("rpc_endpoint", client.endpoint), zap.Uint64("score", score))
+	return score, nil
+}
+
+func (s *ClientScorer) score(client *CometHttpClientWrap) uint64 {
+	latencyMs, errorRate, sampled := client.stats.snapshot()
+	if !sampled && errorRate > 0 {
+		// endpoints that failed without ever serving a block are scored as slow ones, the others
+		// have no latency cost yet so they are tried first and get an accurate score
+		latencyMs = float64(s.failedUnsampledLatency.Milliseconds())
+	}
+
+	cost := latencyMs * (1 + s.errorRateModifier*errorRate)
+
+	head := s.headTracker.LatestReportedBlockNum()
+	if _, latest := client.BlockRange(); latest > 0 && uint64(latest) < head {
+		cost += float64(head-uint64(latest)) * float64(s.blockLagPenalty.Milliseconds())
+	}
+
+	return uint64(cost)
+}
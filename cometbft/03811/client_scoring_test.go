@@ -0,0 +1,62 @@
+package v03811
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestClientScorer_FetchSortValue(t *testing.T) {
+	headTracker := NewHeadTracker(nil, time.Second, time.Minute, nil, zap.NewNop())
+	headTracker.latestReported.Store(100)
+	scorer := NewClientScorer(headTracker, 10*time.Millisecond, zap.NewNop())
+
+	newClient := func(latest int64) *CometHttpClientWrap {
+		client := &CometHttpClientWrap{}
+		client.latestBlockHeight.Store(latest)
+		return client
+	}
+
+	unsampled := newClient(100)
+
+	sampled := newClient(100)
+	sampled.stats.recordLatency(200 * time.Millisecond)
+
+	lagging := newClient(90)
+	lagging.stats.recordLatency(200 * time.Millisecond)
+
+	erroring := newClient(100)
+	erroring.stats.recordLatency(200 * time.Millisecond)
+	erroring.recordFailure()
+
+	failedUnsampled := newClient(100)
+	failedUnsampled.recordFailure()
+
+	statusFailed := newClient(100)
+	statusFailed.statusFailed.Store(true)
+
+	cases := []struct {
+		name   string
+		client *CometHttpClientWrap
+		want   uint64
+	}{
+		{"unsampled is tried first", unsampled, 0},
+		{"sampled", sampled, 200},
+		{"lagging", lagging, 200 + 10*10},
+		{"erroring", erroring, 200 * (1 + 10*statsDecay)},
+		{"failed without serving a block", failedUnsampled, 1000 * (1 + 10*statsDecay)},
+		{"status refresh failed", statusFailed, math.MaxUint64},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			score, err := scorer.FetchSortValue(context.Background(), c.client)
+			require.NoError(t, err)
+			require.Equal(t, c.want, score)
+		})
+	}
+}
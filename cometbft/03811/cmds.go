@@ -1,13 +1,17 @@
 package v03811
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
-	cometBftHttp "github.com/cometbft/cometbft/rpc/client/http"
 	"github.com/spf13/cobra"
 	"github.com/streamingfast/cli/sflags"
+	"github.com/streamingfast/derr"
 	firecore "github.com/streamingfast/firehose-core"
 	"github.com/streamingfast/firehose-core/blockpoller"
 	firecoreRPC "github.com/streamingfast/firehose-core/rpc"
@@ -24,8 +28,42 @@ func NewFetchCmd(logger *zap.Logger, tracer logging.Tracer) *cobra.Command {
 	}
 
 	cmd.Flags().StringArray("endpoints", []string{"https://sentry.tm.injective.network:443"}, "interval between fetch")
+	cmd.Flags().StringArray("archive-endpoints", nil, "endpoints holding the full chain history, serving blocks older than '--recent-blocks-window'")
+	cmd.Flags().Uint64("recent-blocks-window", 1000, "distance from the head under which blocks are fetched from '--endpoints' rather than '--archive-endpoints'")
 	cmd.Flags().String("state-dir", "/data/fetcher", "interval between fetch")
 	cmd.Flags().Duration("latest-block-retry-interval", time.Second, "interval between fetch")
+	cmd.Flags().Duration("endpoint-status-refresh-interval", 30*time.Second, "interval between refreshes of the block range held by each endpoint")
+	cmd.Flags().String("client-selection-strategy", "sticky", "endpoint selection strategy, one of 'sticky' or 'scored' (by latency, error rate and head lag)")
+	cmd.Flags().Duration("client-scoring-interval", 10*time.Second, "interval between endpoint rankings of the 'scored' strategy")
+	cmd.Flags().Duration("client-block-lag-penalty", time.Second, "latency added to an endpoint score per block it lags behind, 'scored' strategy only")
+	cmd.Flags().Float64("hedge-percentile", 0, "if set (e.g. 0.95), send a block fetch to a second endpoint after this percentile of recent latencies, batched fetches are never hedged")
+	cmd.Flags().Duration("hedge-min-delay", 500*time.Millisecond, "minimum time to wait on an endpoint before hedging a block fetch")
+	cmd.Flags().Int("verification-quorum", 0, "if set (at least 2), only emit blocks served identically by this many endpoints")
+	cmd.Flags().Float64("verification-sample-rate", 1, "fraction of the blocks verified when '--verification-quorum' is set")
+	cmd.Flags().String("verification-mismatch-policy", "refuse", "what to do when endpoints disagree on a block, one of 'refuse' or 'quarantine' (emit the majority block and skip the other endpoints)")
+	cmd.Flags().Duration("verification-quarantine-duration", 10*time.Minute, "how long an endpoint disagreeing with the majority is skipped")
+	cmd.Flags().String("block-id-mismatch-policy", "use-reported", "what to do when the header hash differs from the BlockID returned by the endpoint, one of 'use-reported' or 'fail'")
+	cmd.Flags().String("integrity-checks", "", "if set, check the txs and tx results hashes of each block once the next one is available, one of 'flag' or 'fail'")
+	cmd.Flags().Bool("light-client-verification", false, "refuse blocks whose commit cannot be verified from the checkpoint kept in '--state-dir', not supported with a patched header hashing")
+	cmd.Flags().Int64("light-client-trusted-height", 0, "height of the trusted block to start verifying from when no checkpoint is kept yet")
+	cmd.Flags().String("light-client-trusted-hash", "", "hex encoded hash of the block at '--light-client-trusted-height'")
+	cmd.Flags().Duration("light-client-trusting-period", 14*24*time.Hour, "maximum time between a trusted block and a block verified from it, lower than the unbonding period")
+	cmd.Flags().Duration("light-client-max-clock-drift", 10*time.Second, "maximum time a verified header may be ahead of its trusted block")
+	cmd.Flags().Bool("embed-validator-set", false, "embed the validator set of each block")
+	cmd.Flags().String("vote-extensions-source", "", "if set, where to read the vote extensions of each block from, only 'injected-tx' is supported")
+	cmd.Flags().Bool("lossless-event-attributes", false, "keep the index flag and raw bytes of event attributes, raw bytes are already lost over rpc")
+	cmd.Flags().String("utf8-policy", "replace", "what to do with invalid UTF-8 in abci results, one of 'replace', 'keep-raw' or 'fail', invalid bytes are already replaced over rpc")
+	cmd.Flags().Bool("decode-txs", false, "decode the Cosmos SDK envelope of each tx")
+	cmd.Flags().String("latest-block-websocket-endpoint", "", "if set, follow the head through a websocket subscription on this endpoint, polling while it is down")
+	cmd.Flags().Duration("latest-block-websocket-timeout", 30*time.Second, "time without a new header after which the websocket subscription is restarted")
+	cmd.Flags().String("metrics-listen-addr", "", "if set, serve the prometheus metrics on this address, it must differ from the firecore one")
+	cmd.Flags().Duration("shutdown-timeout", 30*time.Second, "maximum time to wait for in-flight fetches and the state flush on termination")
+	cmd.Flags().String("endpoint-auth-config", "", "path to a YAML file holding the credentials of each endpoint (see README)")
+	cmd.Flags().Float64("endpoint-rate-limit", 0, "default maximum requests per second per endpoint, 0 means unlimited")
+	cmd.Flags().Int("endpoint-rate-limit-burst", 1, "default requests allowed at once above the rate limit")
+	cmd.Flags().StringArray("endpoint-rate-limit-override", nil, "rate limit of a single endpoint as '<endpoint>=<rps>[:<burst>]', circuit breaker settings cannot be overridden")
+	cmd.Flags().Int("circuit-breaker-failures", 5, "failed requests in a row after which an endpoint is short-circuited, 0 disables the circuit breaker")
+	cmd.Flags().Duration("circuit-breaker-cooldown", 30*time.Second, "time an endpoint is short-circuited before a probe request is let through")
 	cmd.Flags().Int("block-fetch-batch-size", 10, "Number of blocks to fetch in a single batch")
 
 	return cmd
@@ -34,6 +72,7 @@ func NewFetchCmd(logger *zap.Logger, tracer logging.Tracer) *cobra.Command {
 func fetchRunE(logger *zap.Logger, _ logging.Tracer) firecore.CommandExecutor {
 	return func(cmd *cobra.Command, args []string) (err error) {
 		rpcEndpoints := sflags.MustGetStringArray(cmd, "endpoints")
+		archiveRPCEndpoints := sflags.MustGetStringArray(cmd, "archive-endpoints")
 
 		stateDir := sflags.MustGetString(cmd, "state-dir")
 
@@ -44,24 +83,187 @@ func fetchRunE(logger *zap.Logger, _ logging.Tracer) firecore.CommandExecutor {
 
 		logger.Info(
 			"launching firehose-cosmos fetcher",
-			zap.Strings("rpc_endpoint", rpcEndpoints),
+			zap.Strings("rpc_endpoint", redactEndpoints(rpcEndpoints)),
+			zap.Strings("archive_rpc_endpoint", redactEndpoints(archiveRPCEndpoints)),
 			zap.String("state_dir", stateDir),
 			zap.Uint64("first_streamable_block", startBlock),
 			zap.Duration("latest_block_retry_interval", sflags.MustGetDuration(cmd, "latest-block-retry-interval")),
 		)
 
-		wrappedCometHttpClients := firecoreRPC.NewClients[*CometHttpClientWrap](10*time.Second, firecoreRPC.NewStickyRollingStrategy[*CometHttpClientWrap](), logger)
-		for _, rpcEndpoint := range rpcEndpoints {
-			client, err := cometBftHttp.New(rpcEndpoint, "")
+		if metricsListenAddr := sflags.MustGetString(cmd, "metrics-listen-addr"); metricsListenAddr != "" {
+			RegisterMetrics()
+			if err := ServeMetrics(metricsListenAddr, logger); err != nil {
+				return err
+			}
+		}
+
+		ctx, cancel := context.WithCancel(cmd.Context())
+		defer cancel()
+
+		clientSelectionStrategy := sflags.MustGetString(cmd, "client-selection-strategy")
+		var rollingStrategy firecoreRPC.RollingStrategy[*CometHttpClientWrap]
+		switch clientSelectionStrategy {
+		case "sticky":
+			rollingStrategy = firecoreRPC.NewStickyRollingStrategy[*CometHttpClientWrap]()
+		case "scored":
+			rollingStrategy = firecoreRPC.NewRollingStrategyAlwaysUseFirst[*CometHttpClientWrap]()
+		default:
+			return fmt.Errorf("invalid client selection strategy %q, expected 'sticky' or 'scored'", clientSelectionStrategy)
+		}
+
+		defaultLimits := EndpointLimits{
+			RequestsPerSecond: sflags.MustGetFloat64(cmd, "endpoint-rate-limit"),
+			Burst:             sflags.MustGetInt(cmd, "endpoint-rate-limit-burst"),
+			FailureThreshold:  sflags.MustGetInt(cmd, "circuit-breaker-failures"),
+			Cooldown:          sflags.MustGetDuration(cmd, "circuit-breaker-cooldown"),
+		}
+		endpointLimits := map[string]EndpointLimits{}
+		for _, override := range sflags.MustGetStringArray(cmd, "endpoint-rate-limit-override") {
+			endpoint, requestsPerSecond, burst, err := ParseEndpointRateLimit(override)
 			if err != nil {
-				return fmt.Errorf("creating rpc client: %w", err)
+				return err
+			}
+			if !slices.Contains(rpcEndpoints, endpoint) && !slices.Contains(archiveRPCEndpoints, endpoint) {
+				return fmt.Errorf("endpoint rate limit override references unknown endpoint %q", RedactEndpoint(endpoint))
+			}
+			limits := defaultLimits
+			limits.RequestsPerSecond = requestsPerSecond
+			limits.Burst = burst
+			endpointLimits[endpoint] = limits
+		}
+
+		wsEndpoint := sflags.MustGetString(cmd, "latest-block-websocket-endpoint")
+
+		var endpointAuths map[string]*EndpointAuth
+		if authConfig := sflags.MustGetString(cmd, "endpoint-auth-config"); authConfig != "" {
+			if endpointAuths, err = LoadEndpointAuthConfig(authConfig); err != nil {
+				return err
+			}
+			for endpoint := range endpointAuths {
+				if !slices.Contains(rpcEndpoints, endpoint) && !slices.Contains(archiveRPCEndpoints, endpoint) && endpoint != wsEndpoint {
+					return fmt.Errorf("endpoint auth config references unknown endpoint %q", RedactEndpoint(endpoint))
+				}
+			}
+		}
+
+		var clients []*CometHttpClientWrap
+		wrappedCometHttpClients := firecoreRPC.NewClients[*CometHttpClientWrap](10*time.Second, rollingStrategy, logger)
+		for _, tieredEndpoints := range []struct {
+			tier      EndpointTier
+			endpoints []string
+		}{
+			{EndpointTierArchive, archiveRPCEndpoints},
+			{EndpointTierRecent, rpcEndpoints},
+		} {
+			for _, rpcEndpoint := range tieredEndpoints.endpoints {
+				limits, found := endpointLimits[rpcEndpoint]
+				if !found {
+					limits = defaultLimits
+				}
+				wrappedClient, err := NewCometHttpClientWrap(rpcEndpoint, tieredEndpoints.tier, limits, endpointAuths[rpcEndpoint], logger)
+				if err != nil {
+					return fmt.Errorf("creating client for endpoint %q: %w", RedactEndpoint(rpcEndpoint), redactEndpointError(err, rpcEndpoint))
+				}
+				wrappedCometHttpClients.Add(wrappedClient)
+				clients = append(clients, wrappedClient)
 			}
-			wrappedCometHttpClients.Add(NewCometHttpClientWrap(rpcEndpoint, client))
 		}
 
 		latestBlockRetryInterval := sflags.MustGetDuration(cmd, "latest-block-retry-interval")
+		blockFetchBatchSize := sflags.MustGetInt(cmd, "block-fetch-batch-size")
+
+		var headWatcher *WebsocketHeadWatcher
+		if wsEndpoint != "" {
+			headWatcher, err = NewWebsocketHeadWatcher(wsEndpoint, endpointAuths[wsEndpoint], sflags.MustGetDuration(cmd, "latest-block-websocket-timeout"), logger)
+			if err != nil {
+				return fmt.Errorf("creating websocket head watcher for endpoint %q: %w", RedactEndpoint(wsEndpoint), redactEndpointError(err, wsEndpoint))
+			}
+			go headWatcher.Run(ctx)
+		}
+
+		headTracker := NewHeadTracker(clients, latestBlockRetryInterval, sflags.MustGetDuration(cmd, "endpoint-status-refresh-interval"), headWatcher, logger)
+		go headTracker.Run(ctx)
+
+		if clientSelectionStrategy == "scored" {
+			scorer := NewClientScorer(headTracker, sflags.MustGetDuration(cmd, "client-block-lag-penalty"), logger)
+			wrappedCometHttpClients.StartSorting(ctx, firecoreRPC.SortDirectionAscending, scorer, sflags.MustGetDuration(cmd, "client-scoring-interval"))
+		}
+
+		router := NewEndpointRouter(clients, headTracker, sflags.MustGetUint64(cmd, "recent-blocks-window"))
+
+		blockIDPolicy, err := ParseBlockIDMismatchPolicy(sflags.MustGetString(cmd, "block-id-mismatch-policy"))
+		if err != nil {
+			return err
+		}
+		utf8Policy, err := ParseUTF8Policy(sflags.MustGetString(cmd, "utf8-policy"))
+		if err != nil {
+			return err
+		}
+		var voteExtensionsSource VoteExtensionsSource
+		if source := sflags.MustGetString(cmd, "vote-extensions-source"); source != "" {
+			if voteExtensionsSource, err = ParseVoteExtensionsSource(source); err != nil {
+				return err
+			}
+		}
+		fetcherOptions := []RPCFetcherOption{
+			WithConversionOptions(ConversionOptions{
+				LosslessEventAttributes: sflags.MustGetBool(cmd, "lossless-event-attributes"),
+				UTF8Policy:              utf8Policy,
+				DecodeTxs:               sflags.MustGetBool(cmd, "decode-txs"),
+				BlockIDPolicy:           blockIDPolicy,
+				VoteExtensions:          voteExtensionsSource,
+			}),
+		}
+		if hedgePercentile := sflags.MustGetFloat64(cmd, "hedge-percentile"); hedgePercentile > 0 {
+			if hedgePercentile >= 1 {
+				return fmt.Errorf("invalid hedge percentile %f, expected a value between 0 and 1", hedgePercentile)
+			}
+			fetcherOptions = append(fetcherOptions, WithHedging(NewHedger(router, hedgePercentile, sflags.MustGetDuration(cmd, "hedge-min-delay"), logger)))
+		}
+
+		if verificationQuorum := sflags.MustGetInt(cmd, "verification-quorum"); verificationQuorum > 0 {
+			if verificationQuorum > len(clients) {
+				return fmt.Errorf("verification quorum %d is higher than the %d configured endpoints", verificationQuorum, len(clients))
+			}
+			verifier, err := NewVerifier(
+				router,
+				verificationQuorum,
+				sflags.MustGetFloat64(cmd, "verification-sample-rate"),
+				MismatchPolicy(sflags.MustGetString(cmd, "verification-mismatch-policy")),
+				sflags.MustGetDuration(cmd, "verification-quarantine-duration"),
+				logger,
+			)
+			if err != nil {
+				return err
+			}
+			fetcherOptions = append(fetcherOptions, WithVerification(verifier))
+		}
+
+		if integrityPolicy := sflags.MustGetString(cmd, "integrity-checks"); integrityPolicy != "" {
+			checker, err := NewIntegrityChecker(headTracker, IntegrityPolicy(integrityPolicy), logger)
+			if err != nil {
+				return err
+			}
+			fetcherOptions = append(fetcherOptions, WithIntegrityChecks(checker))
+		}
+
+		if sflags.MustGetBool(cmd, "light-client-verification") {
+			var initialCheckpoint *LightClientCheckpoint
+			if trustedHeight := sflags.MustGetInt64(cmd, "light-client-trusted-height"); trustedHeight > 0 {
+				initialCheckpoint = &LightClientCheckpoint{Height: trustedHeight, Hash: strings.ToLower(sflags.MustGetString(cmd, "light-client-trusted-hash"))}
+			}
+			verifier, err := NewLightClientVerifier(stateDir, initialCheckpoint, sflags.MustGetDuration(cmd, "light-client-trusting-period"), sflags.MustGetDuration(cmd, "light-client-max-clock-drift"), logger)
+			if err != nil {
+				return err
+			}
+			fetcherOptions = append(fetcherOptions, WithLightClientVerification(verifier))
+		}
 
-		rpcFetcher := NewRPCFetcher(latestBlockRetryInterval, logger)
+		if sflags.MustGetBool(cmd, "embed-validator-set") {
+			fetcherOptions = append(fetcherOptions, WithValidatorSetSnapshots(NewValidatorSetSnapshotter(logger)))
+		}
+
+		rpcFetcher := NewRPCFetcher(headTracker, router, blockFetchBatchSize, logger, fetcherOptions...)
 		poller := blockpoller.New[*CometHttpClientWrap](
 			rpcFetcher,
 			blockpoller.NewFireBlockHandler("type.googleapis.com/sf.cosmos.type.v2.Block"),
@@ -70,11 +272,53 @@ func fetchRunE(logger *zap.Logger, _ logging.Tracer) firecore.CommandExecutor {
 			blockpoller.WithLogger[*CometHttpClientWrap](logger),
 		)
 
-		err = poller.Run(startBlock, nil, sflags.MustGetInt(cmd, "block-fetch-batch-size"))
-		if err != nil {
-			return fmt.Errorf("running fetcher: %w", err)
+		runDone := make(chan error, 1)
+		go func() {
+			runDone <- poller.Run(startBlock, nil, blockFetchBatchSize)
+		}()
+
+		select {
+		case err := <-runDone:
+			if err != nil {
+				return fmt.Errorf("running fetcher: %w", err)
+			}
+			return nil
+		case sig := <-derr.SetupSignalHandler(0):
+			logger.Info("received termination signal, shutting down fetcher", zap.Stringer("signal", sig))
+		}
+
+		shutdownTimeout := sflags.MustGetDuration(cmd, "shutdown-timeout")
+		shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancelShutdown()
+
+		// the poller saves its state after each processed segment, waiting for it to return
+		// ensures we never exit while the state file is being written
+		rpcFetcher.Shutdown()
+		poller.Shutdown(nil)
+		cancel()
+
+		select {
+		case err := <-runDone:
+			if err != nil && !errors.Is(err, ErrFetcherShuttingDown) {
+				logger.Warn("fetcher stopped with error during shutdown", zap.Error(err))
+			}
+		case <-shutdownCtx.Done():
+			return fmt.Errorf("poller did not stop within %s", shutdownTimeout)
+		}
+
+		if err := rpcFetcher.Drain(shutdownCtx); err != nil {
+			return err
 		}
 
+		logger.Info("fetcher shut down cleanly")
 		return nil
 	}
 }
+
+func redactEndpoints(endpoints []string) []string {
+	redacted := make([]string, len(endpoints))
+	for i, endpoint := range endpoints {
+		redacted[i] = RedactEndpoint(endpoint)
+	}
+	return redacted
+}
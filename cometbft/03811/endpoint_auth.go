@@ -0,0 +1,255 @@
+package v03811
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EndpointAuth holds the credentials sent to an endpoint. Header values and the basic auth
+// username and password are secret references: `env:<NAME>` reads the environment variable,
+// `file:<path>` reads the file (trailing newlines are trimmed) and any other value is used as is.
+type EndpointAuth struct {
+	Headers   map[string]string  `yaml:"headers"`
+	BasicAuth *EndpointBasicAuth `yaml:"basic_auth"`
+	TLS       *EndpointTLS       `yaml:"tls"`
+}
+
+type EndpointBasicAuth struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// EndpointTLS points to PEM files, the client certificate and key are used for mutual TLS and
+// the CA replaces the system roots to verify the endpoint certificate.
+type EndpointTLS struct {
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	CAFile   string `yaml:"ca_file"`
+}
+
+// LoadEndpointAuthConfig reads a YAML file mapping endpoints to their credentials:
+//
+//	endpoints:
+//	  https://rpc.provider.com:443:
+//	    headers:
+//	      X-Api-Key: env:PROVIDER_API_KEY
+//	    basic_auth:
+//	      username: firehose
+//	      password: file:/secrets/provider-password
+//	    tls:
+//	      cert_file: /secrets/client.crt
+//	      key_file: /secrets/client.key
+//	      ca_file: /secrets/ca.crt
+func LoadEndpointAuthConfig(path string) (map[string]*EndpointAuth, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading endpoint auth config: %w", err)
+	}
+
+	var config struct {
+		Endpoints map[string]*EndpointAuth `yaml:"endpoints"`
+	}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("decoding endpoint auth config %q: %w", path, err)
+	}
+	return config.Endpoints, nil
+}
+
+func resolveSecret(ref string) (string, error) {
+	switch {
+	case strings.HasPrefix(ref, "env:"):
+		name := strings.TrimPrefix(ref, "env:")
+		value, found := os.LookupEnv(name)
+		if !found {
+			return "", fmt.Errorf("environment variable %q is not set", name)
+		}
+		return value, nil
+	case strings.HasPrefix(ref, "file:"):
+		path := strings.TrimPrefix(ref, "file:")
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("reading secret file: %w", err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+	return ref, nil
+}
+
+// resolve resolves the secrets of the auth config, the basic auth credentials are returned as an
+// `Authorization` header. tlsConfig is nil when no TLS setting is configured.
+func (a *EndpointAuth) resolve() (headers http.Header, tlsConfig *tls.Config, err error) {
+	headers = http.Header{}
+	for name, ref := range a.Headers {
+		value, err := resolveSecret(ref)
+		if err != nil {
+			return nil, nil, fmt.Errorf("resolving header %q: %w", name, err)
+		}
+		headers.Set(name, value)
+	}
+
+	if a.BasicAuth != nil {
+		username, err := resolveSecret(a.BasicAuth.Username)
+		if err != nil {
+			return nil, nil, fmt.Errorf("resolving basic auth username: %w", err)
+		}
+		password, err := resolveSecret(a.BasicAuth.Password)
+		if err != nil {
+			return nil, nil, fmt.Errorf("resolving basic auth password: %w", err)
+		}
+		headers.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+	}
+
+	if a.TLS != nil {
+		if tlsConfig, err = a.TLS.config(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return headers, tlsConfig, nil
+}
+
+// authTransport adds the endpoint credentials to every request.
+type authTransport struct {
+	headers http.Header
+	next    http.RoundTripper
+}
+
+// newAuthTransport resolves the secrets of the auth config and returns the transport sending
+// them, the TLS settings are applied to the base transport.
+func newAuthTransport(auth *EndpointAuth, base *http.Transport) (http.RoundTripper, error) {
+	headers, tlsConfig, err := auth.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	if tlsConfig != nil {
+		base.TLSClientConfig = tlsConfig
+	}
+
+	return &authTransport{headers: headers, next: base}, nil
+}
+
+func (t *EndpointTLS) config() (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if t.CertFile != "" || t.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading tls client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if t.CAFile != "" {
+		ca, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading tls ca: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificate found in tls ca %q", t.CAFile)
+		}
+		config.RootCAs = pool
+	}
+
+	return config, nil
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, values := range t.headers {
+		req.Header[name] = values
+	}
+	return t.next.RoundTrip(req)
+}
+
+// RedactEndpoint returns the endpoint without its user info, path segments and query values so
+// that it can be logged, credentials are often passed this way (e.g. `https://host/<api key>/`).
+func RedactEndpoint(endpoint string) string {
+	u, err := parseEndpoint(endpoint)
+	if err != nil {
+		return "<unparseable endpoint>"
+	}
+
+	redacted := u.Scheme + "://"
+	if u.User != nil {
+		redacted += "redacted@"
+	}
+	redacted += u.Host + redactPath(u)
+
+	if u.RawQuery != "" {
+		query := u.Query()
+		for name := range query {
+			query.Set(name, "redacted")
+		}
+		redacted += "?" + query.Encode()
+	}
+	return redacted
+}
+
+func parseEndpoint(endpoint string) (*url.URL, error) {
+	if !strings.Contains(endpoint, "://") {
+		// same default scheme as the rpc client
+		endpoint = "tcp://" + endpoint
+	}
+	return url.Parse(endpoint)
+}
+
+// redactPath replaces every segment of the endpoint path, except for unix sockets whose path is
+// the socket file.
+func redactPath(u *url.URL) string {
+	if u.Scheme == "unix" {
+		return u.Path
+	}
+
+	segments := strings.Split(u.Path, "/")
+	for i, segment := range segments {
+		if segment != "" {
+			segments[i] = "redacted"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// redactedError is an error whose message was redacted, it still unwraps to the original error.
+type redactedError struct {
+	err     error
+	message string
+}
+
+func (e *redactedError) Error() string { return e.message }
+func (e *redactedError) Unwrap() error { return e.err }
+
+// redactEndpointError redacts the endpoint in the error message, the http client includes the
+// requested url in its errors (`Post "https://host/<api key>/": ...`).
+func redactEndpointError(err error, endpoint string) error {
+	if err == nil {
+		return nil
+	}
+
+	u, parseErr := parseEndpoint(endpoint)
+	if parseErr != nil {
+		return err
+	}
+
+	message := strings.ReplaceAll(err.Error(), endpoint, RedactEndpoint(endpoint))
+	if u.Scheme != "unix" {
+		// the rpc clients send requests to the endpoint without its user info and query
+		redactedHostPath := u.Host + redactPath(u)
+		message = strings.ReplaceAll(message, u.Host+u.EscapedPath(), redactedHostPath)
+		message = strings.ReplaceAll(message, u.Host+u.Path, redactedHostPath)
+	}
+
+	if message == err.Error() {
+		return err
+	}
+	return &redactedError{err: err, message: message}
+}
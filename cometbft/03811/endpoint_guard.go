@@ -0,0 +1,307 @@
+package v03811
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+var ErrCircuitOpen = errors.New("endpoint circuit breaker is open")
+var ErrEndpointPaused = errors.New("endpoint asked to retry later")
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitClosed:
+		return "closed"
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// EndpointLimits configures the protection applied to the requests sent to an endpoint.
+type EndpointLimits struct {
+	// RequestsPerSecond is the token bucket refill rate, 0 means unlimited
+	RequestsPerSecond float64
+	// Burst is the token bucket size
+	Burst int
+	// FailureThreshold is the number of failed requests in a row opening the circuit, 0 disables the breaker
+	FailureThreshold int
+	// Cooldown is how long the circuit stays open before a single probe request is let through
+	Cooldown time.Duration
+}
+
+// ParseEndpointRateLimit parses a per endpoint rate limit in the form
+// `<endpoint>=<requests per second>[:<burst>]`. Circuit breaker settings have no per endpoint
+// override.
+func ParseEndpointRateLimit(in string) (endpoint string, requestsPerSecond float64, burst int, err error) {
+	idx := strings.LastIndex(in, "=")
+	if idx <= 0 {
+		return "", 0, 0, fmt.Errorf("invalid endpoint rate limit %q, expected <endpoint>=<requests per second>[:<burst>]", RedactEndpoint(in))
+	}
+	endpoint, limit := in[:idx], in[idx+1:]
+
+	rps, burstValue, hasBurst := strings.Cut(limit, ":")
+	if requestsPerSecond, err = strconv.ParseFloat(rps, 64); err != nil || requestsPerSecond < 0 {
+		return "", 0, 0, fmt.Errorf("invalid requests per second %q in rate limit of endpoint %q", rps, RedactEndpoint(endpoint))
+	}
+
+	burst = 1
+	if hasBurst {
+		if burst, err = strconv.Atoi(burstValue); err != nil || burst < 1 {
+			return "", 0, 0, fmt.Errorf("invalid burst %q in rate limit of endpoint %q", burstValue, RedactEndpoint(endpoint))
+		}
+	}
+	return endpoint, requestsPerSecond, burst, nil
+}
+
+// endpointGuard is an http.RoundTripper rate limiting the requests sent to an endpoint with a
+// token bucket and short-circuiting them while the endpoint is failing. The circuit opens after
+// too many failed requests in a row, and lets a single probe request through once the cooldown is
+// over: the circuit closes if it succeeds and opens again otherwise. An endpoint answering with a
+// `Retry-After` is not failing, requests are held until the requested delay is over, or refused
+// right away when it ends after their deadline.
+type endpointGuard struct {
+	endpoint string
+	limits   EndpointLimits
+	limiter  *rate.Limiter
+	next     http.RoundTripper
+	logger   *zap.Logger
+
+	lock                sync.Mutex
+	state               circuitState
+	consecutiveFailures int
+	openUntil           time.Time
+	probeInFlight       bool
+	pausedUntil         time.Time
+}
+
+func newEndpointGuard(endpoint string, limits EndpointLimits, next http.RoundTripper, logger *zap.Logger) *endpointGuard {
+	g := &endpointGuard{
+		endpoint: endpoint,
+		limits:   limits,
+		next:     next,
+		logger:   logger,
+	}
+	if limits.RequestsPerSecond > 0 {
+		g.limiter = rate.NewLimiter(rate.Limit(limits.RequestsPerSecond), max(limits.Burst, 1))
+	}
+	return g
+}
+
+func (g *endpointGuard) RoundTrip(req *http.Request) (*http.Response, error) {
+	probe, err := g.allow()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.waitPause(req); err != nil {
+		g.release(probe)
+		return nil, err
+	}
+
+	if g.limiter != nil {
+		if err := g.limiter.Wait(req.Context()); err != nil {
+			g.release(probe)
+			return nil, fmt.Errorf("waiting for endpoint rate limit: %w", err)
+		}
+	}
+
+	resp, err := g.next.RoundTrip(req)
+	if err != nil {
+		if req.Context().Err() != nil {
+			// cancelled by the caller, this says nothing about the endpoint
+			g.release(probe)
+			return nil, err
+		}
+		g.recordFailure()
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable:
+		if resp.StatusCode == http.StatusTooManyRequests {
+			rateLimitedResponses.Inc()
+		}
+		if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			g.pauseFor(delay, resp.StatusCode)
+			g.release(probe)
+		} else {
+			g.recordFailure()
+		}
+	case resp.StatusCode >= 500:
+		g.recordFailure()
+	default:
+		g.recordSuccess()
+	}
+	return resp, nil
+}
+
+// allow tells if a request may be sent, probe is true when it is the half-open probe.
+func (g *endpointGuard) allow() (probe bool, err error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	switch g.state {
+	case circuitOpen:
+		if time.Now().Before(g.openUntil) {
+			return false, fmt.Errorf("%w until %s", ErrCircuitOpen, g.openUntil.Format(time.RFC3339))
+		}
+		g.setState(circuitHalfOpen, "cooldown is over")
+		fallthrough
+	case circuitHalfOpen:
+		if g.probeInFlight {
+			return false, fmt.Errorf("%w, waiting on probe request", ErrCircuitOpen)
+		}
+		g.probeInFlight = true
+		return true, nil
+	}
+	return false, nil
+}
+
+func (g *endpointGuard) release(probe bool) {
+	if !probe {
+		return
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.probeInFlight = false
+}
+
+func (g *endpointGuard) recordSuccess() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.consecutiveFailures = 0
+	g.probeInFlight = false
+	if g.state != circuitClosed {
+		g.setState(circuitClosed, "probe request succeeded")
+	}
+}
+
+func (g *endpointGuard) recordFailure() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.consecutiveFailures++
+	g.probeInFlight = false
+	if g.limits.FailureThreshold <= 0 {
+		return
+	}
+
+	switch {
+	case g.state == circuitHalfOpen:
+		g.open(g.limits.Cooldown, "probe request failed")
+	case g.state == circuitClosed && g.consecutiveFailures >= g.limits.FailureThreshold:
+		g.open(g.limits.Cooldown, fmt.Sprintf("%d failed requests in a row", g.consecutiveFailures))
+	}
+}
+
+// waitPause holds the request until the delay requested by the endpoint is over.
+func (g *endpointGuard) waitPause(req *http.Request) error {
+	g.lock.Lock()
+	pausedUntil := g.pausedUntil
+	g.lock.Unlock()
+
+	wait := time.Until(pausedUntil)
+	if wait <= 0 {
+		return nil
+	}
+	if deadline, ok := req.Context().Deadline(); ok && deadline.Before(pausedUntil) {
+		return fmt.Errorf("%w at %s", ErrEndpointPaused, pausedUntil.Format(time.RFC3339))
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return fmt.Errorf("waiting for endpoint retry delay: %w", req.Context().Err())
+	}
+}
+
+func (g *endpointGuard) pauseFor(delay time.Duration, statusCode int) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	pausedUntil := time.Now().Add(delay)
+	if !pausedUntil.After(g.pausedUntil) {
+		return
+	}
+	g.pausedUntil = pausedUntil
+
+	g.logger.Info("endpoint asked to retry later, pausing requests",
+		zap.String("rpc_endpoint", g.endpoint),
+		zap.Int("status_code", statusCode),
+		zap.Duration("delay", delay),
+	)
+}
+
+func (g *endpointGuard) open(delay time.Duration, reason string) {
+	openUntil := time.Now().Add(delay)
+	if g.state == circuitOpen && openUntil.Before(g.openUntil) {
+		return
+	}
+	g.openUntil = openUntil
+	g.setState(circuitOpen, reason)
+}
+
+func (g *endpointGuard) setState(state circuitState, reason string) {
+	if state == circuitOpen && g.state != circuitOpen {
+		circuitBreakerOpened.Inc()
+	}
+
+	g.logger.Info("endpoint circuit breaker state changed",
+		zap.String("rpc_endpoint", g.endpoint),
+		zap.Stringer("from", g.state),
+		zap.Stringer("to", state),
+		zap.String("reason", reason),
+	)
+	g.state = state
+}
+
+// Open tells if requests to the endpoint are currently short-circuited.
+func (g *endpointGuard) Open() bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.state == circuitOpen && time.Now().Before(g.openUntil)
+}
+
+// Paused tells if the endpoint asked to retry later and the delay is not over.
+func (g *endpointGuard) Paused() bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return time.Now().Before(g.pausedUntil)
+}
+
+// parseRetryAfter reads a `Retry-After` header, either a number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
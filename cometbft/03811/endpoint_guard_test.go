@@ -0,0 +1,230 @@
+package v03811
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// guardStep is a request sent through the guard. The endpoint answers with status, or fails
+// when status is 0. expireCooldown ends the open period before the request is sent.
+type guardStep struct {
+	status         int
+	retryAfter     string
+	expireCooldown bool
+
+	wantErr   error
+	wantState circuitState
+}
+
+func TestEndpointGuard_RoundTrip(t *testing.T) {
+	const threshold = 2
+
+	cases := []struct {
+		name      string
+		threshold int
+		steps     []guardStep
+	}{
+		{
+			name:      "opens after failures in a row",
+			threshold: threshold,
+			steps: []guardStep{
+				{status: 500, wantState: circuitClosed},
+				{status: 500, wantState: circuitOpen},
+				{status: 200, wantErr: ErrCircuitOpen, wantState: circuitOpen},
+			},
+		},
+		{
+			name:      "success resets failures",
+			threshold: threshold,
+			steps: []guardStep{
+				{status: 500, wantState: circuitClosed},
+				{status: 200, wantState: circuitClosed},
+				{status: 500, wantState: circuitClosed},
+			},
+		},
+		{
+			name:      "transport errors are failures",
+			threshold: threshold,
+			steps: []guardStep{
+				{status: 0, wantState: circuitClosed},
+				{status: 0, wantState: circuitOpen},
+			},
+		},
+		{
+			name:      "rate limited without retry-after is a failure",
+			threshold: threshold,
+			steps: []guardStep{
+				{status: 429, wantState: circuitClosed},
+				{status: 503, wantState: circuitOpen},
+			},
+		},
+		{
+			name:      "succeeding probe closes",
+			threshold: threshold,
+			steps: []guardStep{
+				{status: 500, wantState: circuitClosed},
+				{status: 500, wantState: circuitOpen},
+				{status: 200, expireCooldown: true, wantState: circuitClosed},
+				{status: 500, wantState: circuitClosed},
+			},
+		},
+		{
+			name:      "failing probe opens again",
+			threshold: threshold,
+			steps: []guardStep{
+				{status: 500, wantState: circuitClosed},
+				{status: 500, wantState: circuitOpen},
+				{status: 500, expireCooldown: true, wantState: circuitOpen},
+				{status: 200, wantErr: ErrCircuitOpen, wantState: circuitOpen},
+			},
+		},
+		{
+			name:      "disabled breaker never opens",
+			threshold: 0,
+			steps: []guardStep{
+				{status: 500, wantState: circuitClosed},
+				{status: 500, wantState: circuitClosed},
+				{status: 0, wantState: circuitClosed},
+				{status: 503, wantState: circuitClosed},
+			},
+		},
+		{
+			name:      "retry-after pauses without opening the circuit",
+			threshold: threshold,
+			steps: []guardStep{
+				{status: 500, wantState: circuitClosed},
+				{status: 429, retryAfter: "3600", wantState: circuitClosed},
+				{status: 200, wantErr: ErrEndpointPaused, wantState: circuitClosed},
+			},
+		},
+		{
+			name:      "retry-after pauses with the breaker disabled",
+			threshold: 0,
+			steps: []guardStep{
+				{status: 503, retryAfter: "3600", wantState: circuitClosed},
+				{status: 200, wantErr: ErrEndpointPaused, wantState: circuitClosed},
+			},
+		},
+		{
+			name:      "retry-after answering the probe keeps it half-open",
+			threshold: threshold,
+			steps: []guardStep{
+				{status: 500, wantState: circuitClosed},
+				{status: 500, wantState: circuitOpen},
+				{status: 429, retryAfter: "0", expireCooldown: true, wantState: circuitHalfOpen},
+				{status: 200, wantState: circuitClosed},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var next guardStep
+			transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				if next.status == 0 {
+					return nil, errors.New("connection refused")
+				}
+				header := http.Header{}
+				if next.retryAfter != "" {
+					header.Set("Retry-After", next.retryAfter)
+				}
+				return &http.Response{StatusCode: next.status, Header: header, Body: io.NopCloser(strings.NewReader(""))}, nil
+			})
+
+			guard := newEndpointGuard("endpoint", EndpointLimits{FailureThreshold: c.threshold, Cooldown: time.Hour}, transport, zap.NewNop())
+			for i, step := range c.steps {
+				next = step
+				if step.expireCooldown {
+					guard.openUntil = time.Now().Add(-time.Second)
+				}
+
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://endpoint", nil)
+				require.NoError(t, err)
+				_, err = guard.RoundTrip(req)
+				cancel()
+
+				if step.wantErr != nil {
+					require.ErrorIs(t, err, step.wantErr, "step %d", i)
+				} else if step.status != 0 {
+					require.NoError(t, err, "step %d", i)
+				}
+				require.Equal(t, step.wantState, guard.state, "step %d", i)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		value     string
+		wantDelay time.Duration
+		wantOK    bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{"0", 0, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second, true},
+		{now.Add(-30 * time.Second).Format(http.TimeFormat), 0, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.value, func(t *testing.T) {
+			delay, ok := parseRetryAfter(c.value, now)
+			require.Equal(t, c.wantOK, ok)
+			require.Equal(t, c.wantDelay, delay)
+		})
+	}
+}
+
+func TestParseEndpointRateLimit(t *testing.T) {
+	cases := []struct {
+		in           string
+		wantEndpoint string
+		wantRPS      float64
+		wantBurst    int
+		wantErr      bool
+	}{
+		{in: "http://node:26657=10", wantEndpoint: "http://node:26657", wantRPS: 10, wantBurst: 1},
+		{in: "http://node:26657=2.5:5", wantEndpoint: "http://node:26657", wantRPS: 2.5, wantBurst: 5},
+		{in: "https://node.example.com/rpc?apikey=secret=0", wantEndpoint: "https://node.example.com/rpc?apikey=secret", wantRPS: 0, wantBurst: 1},
+		{in: "http://node:26657", wantErr: true},
+		{in: "=10", wantErr: true},
+		{in: "http://node:26657=fast", wantErr: true},
+		{in: "http://node:26657=-1", wantErr: true},
+		{in: "http://node:26657=10:0", wantErr: true},
+		{in: "http://node:26657=10:many", wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			endpoint, rps, burst, err := ParseEndpointRateLimit(c.in)
+			if c.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.wantEndpoint, endpoint)
+			require.Equal(t, c.wantRPS, rps)
+			require.Equal(t, c.wantBurst, burst)
+		})
+	}
+}
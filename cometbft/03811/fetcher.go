@@ -3,197 +3,554 @@ package v03811
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
-	"strings"
+	"sync"
 	"time"
-	"unicode/utf8"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/proto/tendermint/types"
-	cometBftHttp "github.com/cometbft/cometbft/rpc/client/http"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	cometType "github.com/cometbft/cometbft/types"
 	pbbstream "github.com/streamingfast/bstream/pb/sf/bstream/v1"
+	"github.com/streamingfast/derr"
 	pbcosmos "github.com/streamingfast/firehose-cosmos/cosmos/pb/sf/cosmos/type/v2"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-type CometHttpClientWrap struct {
-	endpoint        string
-	cometHttpClient *cometBftHttp.HTTP
+var ErrFetcherShuttingDown = errors.New("rpc fetcher is shutting down")
+
+const (
+	eventModeAttribute  = "mode"
+	eventModeBeginBlock = "BeginBlock"
+	eventModeEndBlock   = "EndBlock"
+)
+
+type RPCBlockFetcher struct {
+	headTracker *HeadTracker
+	router      *EndpointRouter
+	batch       *batchFetcher
+	hedger      *Hedger
+	verifier    *Verifier
+	integrity   *IntegrityChecker
+	lightClient *LightClientVerifier
+	validators  *ValidatorSetSnapshotter
+
+	logger *zap.Logger
+
+	conversion ConversionOptions
+
+	shutdownCtx context.Context
+	shutdown    context.CancelFunc
+	inFlight    sync.WaitGroup
 }
 
-func NewCometHttpClientWrap(endpoint string, cometHttpClient *cometBftHttp.HTTP) *CometHttpClientWrap {
-	return &CometHttpClientWrap{
-		endpoint:        endpoint,
-		cometHttpClient: cometHttpClient,
+type RPCFetcherOption func(*RPCBlockFetcher)
+
+// WithHedging sends the block to a second endpoint when the first one is slower than usual,
+// the first valid response wins.
+func WithHedging(hedger *Hedger) RPCFetcherOption {
+	return func(f *RPCBlockFetcher) {
+		hedger.fetchSingle = f.fetch
+		f.hedger = hedger
 	}
 }
 
-type RPCBlockFetcher struct {
-	latestBlockRetryInterval time.Duration
-	latestBlockNum           uint64
-	logger                   *zap.Logger
+// WithVerification compares each sampled block with the one served by other endpoints before
+// emitting it.
+func WithVerification(verifier *Verifier) RPCFetcherOption {
+	return func(f *RPCBlockFetcher) {
+		verifier.fetchSingle = f.fetch
+		f.verifier = verifier
+	}
 }
 
-func NewRPCFetcher(latestBlockRetryInterval time.Duration, logger *zap.Logger) *RPCBlockFetcher {
-	return &RPCBlockFetcher{
-		latestBlockRetryInterval: latestBlockRetryInterval,
-		logger:                   logger,
+// WithIntegrityChecks checks the txs and tx results merkle roots of each block before emitting
+// it.
+func WithIntegrityChecks(checker *IntegrityChecker) RPCFetcherOption {
+	return func(f *RPCBlockFetcher) {
+		checker.batch = f.batch
+		f.integrity = checker
 	}
 }
 
-func (f *RPCBlockFetcher) IsBlockAvailable(requestedSlot uint64) bool {
-	return true
+// WithLightClientVerification refuses blocks whose commit signatures cannot be verified from the
+// trusted checkpoint.
+func WithLightClientVerification(verifier *LightClientVerifier) RPCFetcherOption {
+	return func(f *RPCBlockFetcher) {
+		f.lightClient = verifier
+	}
 }
 
-func (f *RPCBlockFetcher) fetchLatestBlockNum(ctx context.Context, client *CometHttpClientWrap) (uint64, error) {
-	resultChainInfo, err := client.cometHttpClient.BlockchainInfo(ctx, 0, 0)
-	if err != nil {
-		return 0, err
+// WithValidatorSetSnapshots embeds the validator set of each block.
+func WithValidatorSetSnapshots(snapshotter *ValidatorSetSnapshotter) RPCFetcherOption {
+	return func(f *RPCBlockFetcher) {
+		f.validators = snapshotter
 	}
-	return uint64(resultChainInfo.LastHeight), nil
+}
 
+// WithConversionOptions sets how blocks are converted to the Firehose block model.
+func WithConversionOptions(opts ConversionOptions) RPCFetcherOption {
+	return func(f *RPCBlockFetcher) {
+		f.conversion = opts
+	}
+}
+
+func NewRPCFetcher(headTracker *HeadTracker, router *EndpointRouter, blockFetchBatchSize int, logger *zap.Logger, opts ...RPCFetcherOption) *RPCBlockFetcher {
+	shutdownCtx, shutdown := context.WithCancel(context.Background())
+	f := &RPCBlockFetcher{
+		headTracker: headTracker,
+		router:      router,
+		batch:       newBatchFetcher(blockFetchBatchSize, logger),
+		logger:      logger,
+		shutdownCtx: shutdownCtx,
+		shutdown:    shutdown,
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+// IsBlockAvailable tells if the block is below the known head and still held by at least one
+// endpoint, so that the poller does not optimistically fetch blocks nobody can serve.
+func (f *RPCBlockFetcher) IsBlockAvailable(requestedSlot uint64) bool {
+	return requestedSlot <= f.headTracker.LatestBlockNum() && f.router.AnyClientHolds(requestedSlot)
+}
+
+// Shutdown cancels waits and requests of in-flight fetches, new fetches are refused. Errors
+// returned from then on are fatal so the poller does not retry them.
+func (f *RPCBlockFetcher) Shutdown() {
+	f.shutdown()
+}
+
+// Drain blocks until in-flight fetches returned or the context is done.
+func (f *RPCBlockFetcher) Drain(ctx context.Context) error {
+	drained := make(chan struct{})
+	go func() {
+		f.inFlight.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("draining in-flight fetches: %w", ctx.Err())
+	}
 }
 
 func (f *RPCBlockFetcher) Fetch(ctx context.Context, wrappedClient *CometHttpClientWrap, requestBlockNum uint64) (b *pbbstream.Block, skipped bool, err error) {
+	if f.shutdownCtx.Err() != nil {
+		return nil, false, derr.NewFatalError(ErrFetcherShuttingDown)
+	}
+
+	f.inFlight.Add(1)
+	defer f.inFlight.Done()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(f.shutdownCtx, cancel)
+	defer stop()
+
+	f.batch.requested(requestBlockNum)
+	b, skipped, err = f.fetchBlock(ctx, wrappedClient, requestBlockNum)
+	if err != nil && f.shutdownCtx.Err() != nil {
+		return nil, false, derr.NewFatalError(fmt.Errorf("%w: %w", ErrFetcherShuttingDown, err))
+	}
+	if err == nil {
+		f.batch.handOver(requestBlockNum)
+	}
+
+	return b, skipped, err
+}
+
+func (f *RPCBlockFetcher) fetchBlock(ctx context.Context, wrappedClient *CometHttpClientWrap, requestBlockNum uint64) (b *pbbstream.Block, skipped bool, err error) {
 	f.logger.Info("fetching block", zap.Uint64("block_num", requestBlockNum))
 
-	sleepDuration := time.Duration(0)
-	for f.latestBlockNum < requestBlockNum {
-		time.Sleep(sleepDuration)
+	latestBlockNum, err := f.headTracker.WaitFor(ctx, requestBlockNum)
+	if err != nil {
+		return nil, false, fmt.Errorf("waiting for block %d: %w", requestBlockNum, err)
+	}
+	f.logger.Debug("block is available", zap.Uint64("latest_block_num", latestBlockNum), zap.Uint64("requested_block_num", requestBlockNum))
+
+	if err := wrappedClient.refreshStatusFor(ctx, requestBlockNum); err != nil {
+		f.logger.Debug("failed to refresh endpoint status", zap.String("rpc_endpoint", wrappedClient.endpoint), zap.Error(err))
+	}
+
+	// erroring out right away makes the poller roll to the next endpoint
+	if err := f.router.Route(wrappedClient, requestBlockNum); err != nil {
+		return nil, false, err
+	}
 
-		f.latestBlockNum, err = f.fetchLatestBlockNum(ctx, wrappedClient)
+	f.logger.Info("fetching block", zap.Uint64("block_num", requestBlockNum))
+	fetched := f.batch.take(requestBlockNum)
+	if fetched != nil {
+		f.logger.Debug("block served from batch", zap.Uint64("block_num", requestBlockNum), zap.String("rpc_endpoint", fetched.blockClient.endpoint))
+	} else {
+		fetched, err = f.fetchFromEndpoint(ctx, wrappedClient, requestBlockNum)
 		if err != nil {
-			return nil, false, fmt.Errorf("fetching latest block num: %w", err)
+			// a fetch cancelled by the caller says nothing about the endpoint
+			if ctx.Err() == nil {
+				wrappedClient.recordFailure()
+			}
+			return nil, false, fmt.Errorf("fetching block %d: %w", requestBlockNum, err)
+		}
+		if fetched.blockClient == wrappedClient {
+			wrappedClient.recordSuccess()
 		}
+	}
 
-		f.logger.Info("got latest block num", zap.Uint64("latest_block_num", f.latestBlockNum), zap.Uint64("requested_block_num", requestBlockNum))
+	if f.verifier != nil {
+		if fetched, err = f.verifier.verify(ctx, fetched, requestBlockNum); err != nil {
+			return nil, false, err
+		}
+	}
 
-		if f.latestBlockNum >= requestBlockNum {
-			break
+	if f.lightClient != nil {
+		if err := f.lightClient.verify(ctx, wrappedClient, fetched.block); err != nil {
+			return nil, false, err
 		}
-		sleepDuration = f.latestBlockRetryInterval
 	}
 
-	f.logger.Info("fetching block", zap.Uint64("block_num", requestBlockNum))
-	rpcBlockResponse, rpcBlockResults, err := f.fetch(ctx, wrappedClient, requestBlockNum)
+	f.logger.Info("converting block", zap.Uint64("block_num", requestBlockNum), zap.String("block_endpoint", fetched.blockClient.endpoint), zap.String("block_results_endpoint", fetched.blockResultsClient.endpoint))
+	cosmosBlock, err := convertBlock(fetched.block, fetched.blockResults, fetched.blockClient.endpoint, f.conversion, f.logger)
 	if err != nil {
-		return nil, false, fmt.Errorf("fetching block %d: %w", requestBlockNum, err)
+		return nil, false, fmt.Errorf("converting block %d from rpc response: %w", requestBlockNum, err)
+	}
+
+	if f.validators != nil {
+		if cosmosBlock.ValidatorSet, err = f.validators.snapshot(ctx, wrappedClient, &fetched.block.Block.Header); err != nil {
+			return nil, false, err
+		}
 	}
 
-	f.logger.Info("converting block", zap.Uint64("block_num", requestBlockNum))
-	bstreamBlock, err := convertBlockFromResponse(rpcBlockResponse, rpcBlockResults)
+	if f.integrity != nil {
+		if err := f.integrity.check(ctx, wrappedClient, cosmosBlock); err != nil {
+			return nil, false, err
+		}
+	}
+
+	bstreamBlock, err := toBstreamBlock(cosmosBlock, fetched.block.Block.LastBlockID.Hash)
 	if err != nil {
-		return nil, false, fmt.Errorf("converting block %d from rpc response: %w", requestBlockNum, err)
+		return nil, false, fmt.Errorf("converting block %d: %w", requestBlockNum, err)
+	}
+
+	if f.lightClient != nil {
+		if err := f.lightClient.accept(fetched.block); err != nil {
+			return nil, false, fmt.Errorf("accepting block %d: %w", requestBlockNum, err)
+		}
 	}
 
 	return bstreamBlock, false, nil
 }
 
-func (f *RPCBlockFetcher) fetch(ctx context.Context, wrappedClient *CometHttpClientWrap, requestBlockNum uint64) (*ctypes.ResultBlock, *ctypes.ResultBlockResults, error) {
+// fetchFromEndpoint only hedges single block fetches: a batch takes longer than the latencies
+// the hedging delay is computed from, and cancelling it would lose the other blocks it holds.
+func (f *RPCBlockFetcher) fetchFromEndpoint(ctx context.Context, wrappedClient *CometHttpClientWrap, requestBlockNum uint64) (*fetchedBlock, error) {
+	if f.batch.enabled(wrappedClient) {
+		return f.fetchWithBatch(ctx, wrappedClient, requestBlockNum)
+	}
+
+	if f.hedger == nil {
+		return f.fetch(ctx, wrappedClient, requestBlockNum)
+	}
+
+	return f.hedger.fetch(ctx, wrappedClient, requestBlockNum)
+}
+
+// fetchWithBatch sends a new batch starting at the requested height. It falls back to single
+// requests when the batch does not hold the block. Batching stops for good with an endpoint
+// refusing batches, and for a while with an endpoint failing one for another reason.
+func (f *RPCBlockFetcher) fetchWithBatch(ctx context.Context, wrappedClient *CometHttpClientWrap, requestBlockNum uint64) (*fetchedBlock, error) {
+	if !f.batch.enabled(wrappedClient) {
+		return f.fetch(ctx, wrappedClient, requestBlockNum)
+	}
+
+	fetched, err := f.batch.fetch(ctx, wrappedClient, requestBlockNum, f.headTracker.LatestBlockNum())
+	switch {
+	case errors.Is(err, errBatchRejected):
+		f.logger.Warn("endpoint does not support batch requests, disabling batching for it", zap.String("rpc_endpoint", wrappedClient.endpoint), zap.Error(err))
+		f.batch.markRejected(wrappedClient)
+	case err != nil && ctx.Err() == nil:
+		f.logger.Warn("failed to fetch blocks batch, falling back to single requests for a while", zap.Uint64("block_num", requestBlockNum), zap.String("rpc_endpoint", wrappedClient.endpoint), zap.Error(err))
+		f.batch.pause(wrappedClient)
+	case err != nil:
+		return nil, err
+	case fetched != nil:
+		return fetched, nil
+	}
+
+	return f.fetch(ctx, wrappedClient, requestBlockNum)
+}
+
+// fetchedBlock holds the two halves of a block fetched over rpc along with the client that
+// served each of them, so that inconsistencies can be traced back to a provider. Clients are
+// compared by identity, redacted endpoints of different providers may be the same.
+type fetchedBlock struct {
+	block              *ctypes.ResultBlock
+	blockClient        *CometHttpClientWrap
+	blockResults       *ctypes.ResultBlockResults
+	blockResultsClient *CometHttpClientWrap
+}
+
+func (f *RPCBlockFetcher) fetch(ctx context.Context, wrappedClient *CometHttpClientWrap, requestBlockNum uint64) (*fetchedBlock, error) {
 	requestBlockNumAsInt := int64(requestBlockNum)
-	var block *ctypes.ResultBlock
-	var rpcBlockResults *ctypes.ResultBlockResults
+	out := &fetchedBlock{}
 
-	f.logger.Info("fetching block from rpc", zap.Int64("block_num", requestBlockNumAsInt))
+	f.logger.Info("fetching block and block results from rpc", zap.Int64("block_num", requestBlockNumAsInt), zap.String("rpc_endpoint", wrappedClient.endpoint))
 
-	block, err := wrappedClient.cometHttpClient.Block(ctx, &requestBlockNumAsInt)
-	if err != nil {
-		f.logger.Warn("failed to fetch block from rpc", zap.Int64("block_num", requestBlockNumAsInt), zap.Error(err), zap.String("rpc_endpoint", wrappedClient.endpoint))
-		return nil, nil, fmt.Errorf("fetching block %d from rpc endpoint: %w", requestBlockNumAsInt, err)
+	// both calls are sent concurrently, the first one failing cancels the other
+	start := time.Now()
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.Go(func() error {
+		block, err := wrappedClient.cometHttpClient.Block(groupCtx, &requestBlockNumAsInt)
+		if err != nil {
+			err = wrappedClient.redactError(err)
+			f.logger.Warn("failed to fetch block from rpc", zap.Int64("block_num", requestBlockNumAsInt), zap.Error(err), zap.String("rpc_endpoint", wrappedClient.endpoint))
+			return fmt.Errorf("fetching block %d from rpc endpoint: %w", requestBlockNumAsInt, err)
+		}
+		out.block = block
+		out.blockClient = wrappedClient
+		return nil
+	})
+	group.Go(func() error {
+		blockResults, err := wrappedClient.cometHttpClient.BlockResults(groupCtx, &requestBlockNumAsInt)
+		if err != nil {
+			err = wrappedClient.redactError(err)
+			f.logger.Warn("failed to fetch block results from rpc", zap.Int64("block_num", requestBlockNumAsInt), zap.Error(err), zap.String("rpc_endpoint", wrappedClient.endpoint))
+			return fmt.Errorf("fetching block results %d from rpc endpoint: %w", requestBlockNumAsInt, err)
+		}
+		out.blockResults = blockResults
+		out.blockResultsClient = wrappedClient
+		return nil
+	})
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	wrappedClient.stats.recordLatency(time.Since(start))
+
+	if err := out.validate(requestBlockNumAsInt); err != nil {
+		return nil, err
 	}
 
-	f.logger.Info("fetching block results from rpc", zap.Int64("block_num", requestBlockNumAsInt))
-	rpcBlockResults, err = wrappedClient.cometHttpClient.BlockResults(ctx, &requestBlockNumAsInt)
+	return out, nil
+}
+
+func (b *fetchedBlock) validate(requestBlockNum int64) error {
+	if b.block.Block == nil {
+		return fmt.Errorf("block %d served by %q has no content", requestBlockNum, b.blockClient.endpoint)
+	}
+
+	if b.block.Block.Height != requestBlockNum || b.blockResults.Height != requestBlockNum {
+		return fmt.Errorf("height mismatch for requested block %d: block height %d served by %q, block results height %d served by %q", requestBlockNum, b.block.Block.Height, b.blockClient.endpoint, b.blockResults.Height, b.blockResultsClient.endpoint)
+	}
+
+	if len(b.block.Block.Txs) != len(b.blockResults.TxsResults) {
+		return fmt.Errorf("tx count mismatch for block %d: %d txs served by %q, %d tx results served by %q", requestBlockNum, len(b.block.Block.Txs), b.blockClient.endpoint, len(b.blockResults.TxsResults), b.blockResultsClient.endpoint)
+	}
+
+	return nil
+}
+
+// toBstreamBlock wraps the converted block, parentHash is the `LastBlockID` of its header.
+func toBstreamBlock(cosmosBlock *pbcosmos.Block, parentHash []byte) (*pbbstream.Block, error) {
+	payload, err := anypb.New(cosmosBlock)
 	if err != nil {
-		f.logger.Warn("failed to fetch block results from rpc", zap.Int64("block_num", requestBlockNumAsInt), zap.Error(err))
-		return nil, nil, fmt.Errorf("fetching block results %d from rpc endpoint: %w", requestBlockNumAsInt, err)
+		return nil, fmt.Errorf("creating payload: %w", err)
+	}
+
+	bstreamBlock := &pbbstream.Block{
+		Number:    uint64(cosmosBlock.Height),
+		Id:        hex.EncodeToString(cosmosBlock.Hash),
+		ParentId:  hex.EncodeToString(parentHash),
+		Timestamp: cosmosBlock.Time,
+		LibNum:    uint64(cosmosBlock.Height - 1),
+		ParentNum: uint64(cosmosBlock.Height - 1),
+		Payload:   payload,
 	}
 
-	return block, rpcBlockResults, nil
+	return bstreamBlock, nil
+}
+
+// ConversionOptions tunes how cometbft blocks are converted to the Firehose block model, the
+// rpc fetcher and the chain storage loader must be given the same ones to produce identical
+// blocks.
+type ConversionOptions struct {
+	// LosslessEventAttributes keeps the index flag of event attributes, and the original bytes of
+	// the keys and values that are not valid UTF-8, so that the abci results can be reproduced
+	// byte for byte. Rpc responses never hold such bytes, the node JSON encoder replaced them
+	LosslessEventAttributes bool
+	// UTF8Policy is applied to the abci results strings that are not valid UTF-8, replacing their
+	// invalid bytes by default. Only blocks read from chain storage hold such strings
+	UTF8Policy UTF8Policy
+	// DecodeTxs decodes the Cosmos SDK envelope of each tx
+	DecodeTxs bool
+	// BlockIDPolicy is what to do when the block hash computed from the header differs from the
+	// BlockID served with the block, the served BlockID is emitted by default
+	BlockIDPolicy BlockIDMismatchPolicy
+	// VoteExtensions is where the vote extensions of the previous height given to the proposer of
+	// each block are read from, they are left out when empty
+	VoteExtensions VoteExtensionsSource
 }
 
-func convertBlockFromResponse(rpcBlock *ctypes.ResultBlock, rpcBlockResults *ctypes.ResultBlockResults) (*pbbstream.Block, error) {
-	blockTimestamp := timestamppb.New(rpcBlock.Block.Time)
-	blockHeight := rpcBlock.Block.Height
-	blockHash := rpcBlock.Block.Hash()
+// convertBlock builds the Firehose block model out of a cometbft block and its finalize block
+// results. It is shared by the rpc fetcher and the chain storage loader so both paths produce
+// identical blocks, source is the endpoint or storage the block was read from.
+func convertBlock(rpcBlock *ctypes.ResultBlock, blockResults *ctypes.ResultBlockResults, source string, opts ConversionOptions, logger *zap.Logger) (*pbcosmos.Block, error) {
+	block := rpcBlock.Block
+	blockHash, err := checkBlockID(rpcBlock, opts.BlockIDPolicy, source, logger)
+	if err != nil {
+		return nil, err
+	}
 
-	id := hex.EncodeToString(rpcBlock.Block.Hash())
-	parentId := hex.EncodeToString(rpcBlock.Block.LastBlockID.Hash)
+	sanitizer := newUTF8Sanitizer(opts.UTF8Policy)
 
-	misbehaviors, err := MisbehaviorsFromEvidences(rpcBlock.Block.Evidence.Evidence)
+	misbehaviors, err := MisbehaviorsFromEvidences(block.Evidence.Evidence)
 	if err != nil {
 		return nil, fmt.Errorf("converting misbehaviors: %w", err)
 	}
 
-	header, err := convertHeaderFromResponse(&rpcBlock.Block.Header)
+	header, err := convertHeaderFromResponse(&block.Header)
 	if err != nil {
 		return nil, fmt.Errorf("converting header from response: %w", err)
 	}
 
-	txResults, err := convertDeliverTxs(rpcBlockResults.TxsResults)
+	txResults, err := convertDeliverTxs(blockResults.TxsResults, opts, sanitizer)
+	if err != nil {
+		return nil, fmt.Errorf("converting tx results: %w", err)
+	}
 
-	validatorUpdates, err := convertValidatorUpdatesFromResponse(rpcBlockResults.ValidatorUpdates)
+	validatorUpdates, err := convertValidatorUpdatesFromResponse(blockResults.ValidatorUpdates)
 	if err != nil {
 		return nil, fmt.Errorf("converting validator updates: %w", err)
 	}
 
-	consensusParamUpdates, err := convertConsensusParamUpdatesFromResponse(rpcBlockResults.ConsensusParamUpdates)
+	consensusParamUpdates, err := convertConsensusParamUpdatesFromResponse(blockResults.ConsensusParamUpdates)
 	if err != nil {
 		return nil, fmt.Errorf("converting consensus param updates: %w", err)
 	}
 
-	finalEvents := rpcBlockResults.FinalizeBlockEvents
-	events, err := convertEventsFromResponse(finalEvents)
+	lastCommit, err := convertCommitFromResponse(block.LastCommit)
+	if err != nil {
+		return nil, fmt.Errorf("converting last commit: %w", err)
+	}
+
+	finalEvents := blockResults.FinalizeBlockEvents
+	events, err := convertEventsFromResponse(finalEvents, "events", opts, sanitizer)
 	if err != nil {
 		return nil, fmt.Errorf("converting events: %w", err)
 	}
+	sanitizer.report(block.Height, logger)
+	beginBlockEvents, endBlockEvents := splitEventsByMode(events)
 
-	injectiveBlock := &pbcosmos.Block{
+	cosmosBlock := &pbcosmos.Block{
 		Hash:                  blockHash,
-		Height:                blockHeight,
-		Time:                  blockTimestamp,
+		Height:                block.Height,
+		Time:                  timestamppb.New(block.Time),
 		Header:                header,
 		Misbehavior:           misbehaviors,
 		Events:                events,
-		Txs:                   convertTxsFromResponse(rpcBlock.Block.Txs),
+		BeginBlockEvents:      beginBlockEvents,
+		EndBlockEvents:        endBlockEvents,
+		Txs:                   convertTxsFromResponse(block.Txs),
 		TxResults:             txResults,
 		ValidatorUpdates:      validatorUpdates,
 		ConsensusParamUpdates: consensusParamUpdates,
+		LastCommit:            lastCommit,
+		AppHash:               blockResults.AppHash,
 	}
-
-	payload, err := anypb.New(injectiveBlock)
-	if err != nil {
-		return nil, fmt.Errorf("creating payload: %w", err)
+	if opts.DecodeTxs {
+		cosmosBlock.DecodedTxs = decodeTxs(block.Txs)
 	}
-
-	bstreamBlock := &pbbstream.Block{
-		Number:    uint64(blockHeight),
-		Id:        id,
-		ParentId:  parentId,
-		Timestamp: blockTimestamp,
-		LibNum:    uint64(blockHeight - 1),
-		ParentNum: uint64(blockHeight - 1),
-		Payload:   payload,
+	if opts.VoteExtensions == VoteExtensionsSourceInjectedTx {
+		if cosmosBlock.ExtendedCommitInfo, err = extractExtendedCommitInfo(block, logger); err != nil {
+			return nil, fmt.Errorf("extracting vote extensions: %w", err)
+		}
 	}
 
-	return bstreamBlock, nil
+	return cosmosBlock, nil
 }
 
-func convertEventsFromResponse(responseEvents []abci.Event) ([]*pbcosmos.Event, error) {
+// convertEventsFromResponse copies the events field by field rather than through protoFlip, abci
+// events are free form strings that may not be valid UTF-8 which a proto3 `string` refuses.
+func convertEventsFromResponse(responseEvents []abci.Event, location string, opts ConversionOptions, sanitizer *utf8Sanitizer) ([]*pbcosmos.Event, error) {
 	events := make([]*pbcosmos.Event, len(responseEvents))
-	for i := range events {
-		events[i] = &pbcosmos.Event{}
+	for i, responseEvent := range responseEvents {
+		eventType, invalid, err := sanitizer.sanitize(responseEvent.Type, "event_type", "%s[%d].type", location, i)
+		if err != nil {
+			return nil, err
+		}
+		events[i] = &pbcosmos.Event{
+			Type:       eventType,
+			Attributes: make([]*pbcosmos.EventAttribute, len(responseEvent.Attributes)),
+		}
+		if invalid && sanitizer.keepRaw() {
+			events[i].RawType = []byte(responseEvent.Type)
+		}
+
+		for j, responseAttribute := range responseEvent.Attributes {
+			if events[i].Attributes[j], err = convertEventAttribute(responseAttribute, fmt.Sprintf("%s[%d].attributes[%d]", location, i, j), opts, sanitizer); err != nil {
+				return nil, err
+			}
+		}
 	}
+	return events, nil
+}
 
-	err := arrayProtoFlip(arrayToPointerArray(responseEvents), events)
+func convertEventAttribute(responseAttribute abci.EventAttribute, location string, opts ConversionOptions, sanitizer *utf8Sanitizer) (*pbcosmos.EventAttribute, error) {
+	key, invalidKey, err := sanitizer.sanitize(responseAttribute.Key, "attribute_key", "%s.key", location)
 	if err != nil {
-		return nil, fmt.Errorf("converting events: %w", err)
+		return nil, err
 	}
-	return events, nil
+	value, invalidValue, err := sanitizer.sanitize(responseAttribute.Value, "attribute_value", "%s.value", location)
+	if err != nil {
+		return nil, err
+	}
+
+	attribute := &pbcosmos.EventAttribute{
+		Key:   key,
+		Value: value,
+	}
+	if opts.LosslessEventAttributes {
+		attribute.Index = responseAttribute.Index
+	}
+
+	keepRaw := opts.LosslessEventAttributes || sanitizer.keepRaw()
+	if invalidKey && keepRaw {
+		attribute.RawKey = []byte(responseAttribute.Key)
+	}
+	if invalidValue && keepRaw {
+		attribute.RawValue = []byte(responseAttribute.Value)
+	}
+	return attribute, nil
+}
+
+// splitEventsByMode returns the finalize block events emitted by the begin and end blockers, the
+// Cosmos SDK tags them with a `mode` attribute. Events with another mode (such as `PreBlock`) or
+// without one are only part of the combined list.
+func splitEventsByMode(events []*pbcosmos.Event) (beginBlock []*pbcosmos.Event, endBlock []*pbcosmos.Event) {
+	for _, event := range events {
+		for _, attribute := range event.Attributes {
+			if attribute.Key != eventModeAttribute {
+				continue
+			}
+			switch attribute.Value {
+			case eventModeBeginBlock:
+				beginBlock = append(beginBlock, event)
+			case eventModeEndBlock:
+				endBlock = append(endBlock, event)
+			}
+			break
+		}
+	}
+	return beginBlock, endBlock
 }
 
 func convertTxsFromResponse(transactions cometType.Txs) (txs [][]byte) {
@@ -211,6 +568,19 @@ func convertHeaderFromResponse(responseHeader *cometType.Header) (*pbcosmos.Head
 	return header, nil
 }
 
+func convertCommitFromResponse(commit *cometType.Commit) (*pbcosmos.Commit, error) {
+	if commit == nil {
+		return nil, nil
+	}
+
+	out := &pbcosmos.Commit{}
+	err := protoFlip(commit.ToProto(), out)
+	if err != nil {
+		return nil, fmt.Errorf("converting commit: %w", err)
+	}
+	return out, nil
+}
+
 func convertValidatorUpdatesFromResponse(validatorUpdates []abci.ValidatorUpdate) ([]*pbcosmos.ValidatorUpdate, error) {
 	validators := make([]*pbcosmos.ValidatorUpdate, len(validatorUpdates))
 	for i := range validators {
@@ -233,36 +603,58 @@ func convertConsensusParamUpdatesFromResponse(consensusParamUpdates *types.Conse
 	return out, nil
 }
 
-func convertResponseDeliverTx(tx *abci.ExecTxResult) (*pbcosmos.TxResults, error) {
-	events := make([]*pbcosmos.Event, len(tx.Events))
-	for i, _ := range events {
-		events[i] = &pbcosmos.Event{}
-	}
-	err := arrayProtoFlip(arrayToPointerArray(tx.Events), events)
+func convertResponseDeliverTx(tx *abci.ExecTxResult, index int, opts ConversionOptions, sanitizer *utf8Sanitizer) (*pbcosmos.TxResults, error) {
+	events, err := convertEventsFromResponse(tx.Events, fmt.Sprintf("tx_results[%d].events", index), opts, sanitizer)
 	if err != nil {
-		return nil, fmt.Errorf("converting events: %w", err)
+		return nil, err
 	}
 
-	fixedLog := strings.Map(fixUtf, tx.Log)
+	log, invalidLog, err := sanitizer.sanitize(tx.Log, "log", "tx_results[%d].log", index)
+	if err != nil {
+		return nil, err
+	}
+	info, invalidInfo, err := sanitizer.sanitize(tx.Info, "info", "tx_results[%d].info", index)
+	if err != nil {
+		return nil, err
+	}
+	codespace, invalidCodespace, err := sanitizer.sanitize(tx.Codespace, "codespace", "tx_results[%d].codespace", index)
+	if err != nil {
+		return nil, err
+	}
 
 	txResults := &pbcosmos.TxResults{
 		Code:      tx.Code,
 		Data:      tx.Data,
-		Log:       fixedLog,
-		Info:      tx.Info,
+		Log:       log,
+		Info:      info,
 		GasWanted: tx.GasWanted,
 		GasUsed:   tx.GasUsed,
 		Events:    events,
-		Codespace: tx.Codespace,
+		Codespace: codespace,
+	}
+
+	if sanitizer.keepRaw() {
+		if invalidLog {
+			txResults.RawLog = []byte(tx.Log)
+		}
+		if invalidInfo {
+			txResults.RawInfo = []byte(tx.Info)
+		}
+		if invalidCodespace {
+			txResults.RawCodespace = []byte(tx.Codespace)
+		}
 	}
 
 	return txResults, nil
 }
 
-func convertDeliverTxs(txs []*abci.ExecTxResult) ([]*pbcosmos.TxResults, error) {
+func convertDeliverTxs(txs []*abci.ExecTxResult, opts ConversionOptions, sanitizer *utf8Sanitizer) ([]*pbcosmos.TxResults, error) {
 	txResults := make([]*pbcosmos.TxResults, len(txs))
 	for i, tx := range txs {
-		txResults[i], _ = convertResponseDeliverTx(tx)
+		var err error
+		if txResults[i], err = convertResponseDeliverTx(tx, i, opts, sanitizer); err != nil {
+			return nil, err
+		}
 	}
 	return txResults, nil
 }
@@ -287,11 +679,3 @@ func MisbehaviorsFromEvidences(evidences cometType.EvidenceList) ([]*pbcosmos.Mi
 	}
 	return misbehaviors, nil
 }
-
-func fixUtf(r rune) rune {
-	if r == utf8.RuneError {
-		fmt.Println("found rune error")
-		return '�'
-	}
-	return r
-}
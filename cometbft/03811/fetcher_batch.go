@@ -0,0 +1,249 @@
+package v03811
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"go.uber.org/zap"
+)
+
+// batchRetryDelay is how long an endpoint is served with single requests after a batch failed
+// for another reason than the endpoint refusing batches (timeout, rate limiting...).
+const batchRetryDelay = 30 * time.Second
+
+// batchFetcher packs `block` and `block_results` calls for several heights into a single
+// JSON-RPC batch. Blocks are kept in memory until the poller asks for them, and only evicted
+// once every height up to theirs was handed over to the poller. Endpoints refusing batches are
+// remembered and served with single requests from then on.
+type batchFetcher struct {
+	size   int
+	logger *zap.Logger
+
+	lock   sync.Mutex
+	blocks map[uint64]*fetchedBlock
+	// heights queued in a batch being sent, the channel is closed once the batch completed
+	inFlight map[uint64]chan struct{}
+
+	// every height up to passed was handed over to the poller, handedOver holds the ones above
+	// it that were handed over out of order
+	started    bool
+	passed     uint64
+	handedOver map[uint64]bool
+
+	rejectedEndpoints map[*CometHttpClientWrap]bool
+	pausedEndpoints   map[*CometHttpClientWrap]time.Time
+}
+
+func newBatchFetcher(size int, logger *zap.Logger) *batchFetcher {
+	return &batchFetcher{
+		size:              size,
+		logger:            logger,
+		blocks:            map[uint64]*fetchedBlock{},
+		inFlight:          map[uint64]chan struct{}{},
+		handedOver:        map[uint64]bool{},
+		rejectedEndpoints: map[*CometHttpClientWrap]bool{},
+		pausedEndpoints:   map[*CometHttpClientWrap]time.Time{},
+	}
+}
+
+func (b *batchFetcher) enabled(wrappedClient *CometHttpClientWrap) bool {
+	if b.size <= 1 {
+		return false
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return !b.rejectedEndpoints[wrappedClient] && time.Now().After(b.pausedEndpoints[wrappedClient])
+}
+
+func (b *batchFetcher) markRejected(wrappedClient *CometHttpClientWrap) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.rejectedEndpoints[wrappedClient] = true
+}
+
+func (b *batchFetcher) pause(wrappedClient *CometHttpClientWrap) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.pausedEndpoints[wrappedClient] = time.Now().Add(batchRetryDelay)
+}
+
+// requested records that the poller asks for the block. Its workers may reach the fetcher out of
+// order, a block below the ones already handed over moves the watermark back to it.
+func (b *batchFetcher) requested(blockNum uint64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if !b.started {
+		b.started = true
+		b.passed = blockNum - 1
+		return
+	}
+	if blockNum > b.passed {
+		return
+	}
+
+	// heights above it were handed over already, the watermark moves back up to where it was
+	// once the block is handed over
+	for num := blockNum + 1; num <= b.passed; num++ {
+		b.handedOver[num] = true
+	}
+	b.passed = blockNum - 1
+}
+
+// handOver records that the block was returned to the poller, blocks up to the highest height
+// below which everything was handed over are evicted as the poller moved past them.
+func (b *batchFetcher) handOver(blockNum uint64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if blockNum <= b.passed {
+		return
+	}
+
+	b.handedOver[blockNum] = true
+	for b.handedOver[b.passed+1] {
+		delete(b.handedOver, b.passed+1)
+		b.passed++
+	}
+
+	for num := range b.blocks {
+		if num <= b.passed {
+			delete(b.blocks, num)
+		}
+	}
+}
+
+// take returns and forgets the block at the given height.
+func (b *batchFetcher) take(blockNum uint64) *fetchedBlock {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	block, found := b.blocks[blockNum]
+	if found {
+		delete(b.blocks, blockNum)
+	}
+	return block
+}
+
+// peek returns the block at the given height without forgetting it.
+func (b *batchFetcher) peek(blockNum uint64) *fetchedBlock {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.blocks[blockNum]
+}
+
+// reserve picks the heights of a new batch in [startBlockNum, min(startBlockNum+size-1,
+// latestBlockNum)], skipping the ones already cached or queued in another batch. When
+// startBlockNum itself is queued in another batch, no height is returned but the channel
+// closed once that batch completed.
+func (b *batchFetcher) reserve(startBlockNum uint64, latestBlockNum uint64) ([]uint64, chan struct{}) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if pending, found := b.inFlight[startBlockNum]; found {
+		return nil, pending
+	}
+
+	heights := []uint64{startBlockNum}
+	for num := startBlockNum + 1; num < startBlockNum+uint64(b.size) && num <= latestBlockNum; num++ {
+		if _, cached := b.blocks[num]; cached {
+			continue
+		}
+		if _, queued := b.inFlight[num]; queued {
+			continue
+		}
+		heights = append(heights, num)
+	}
+
+	done := make(chan struct{})
+	for _, num := range heights {
+		b.inFlight[num] = done
+	}
+	return heights, done
+}
+
+func (b *batchFetcher) release(heights []uint64, done chan struct{}) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for _, num := range heights {
+		delete(b.inFlight, num)
+	}
+	close(done)
+}
+
+// fetch sends a batch starting at startBlockNum and keeps every block except the first one,
+// which is returned. Heights the endpoint failed to serve are left out. A nil block without
+// error is returned when the batch went through but did not hold the first block, the caller
+// then fetches it with single requests.
+func (b *batchFetcher) fetch(ctx context.Context, wrappedClient *CometHttpClientWrap, startBlockNum uint64, latestBlockNum uint64) (*fetchedBlock, error) {
+	heights, pending := b.reserve(startBlockNum, latestBlockNum)
+	if heights == nil {
+		b.logger.Debug("waiting for block queued in another batch", zap.Uint64("block_num", startBlockNum))
+		select {
+		case <-pending:
+			return b.take(startBlockNum), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	defer b.release(heights, pending)
+
+	blocks := make([]*ctypes.ResultBlock, len(heights))
+	blockResults := make([]*ctypes.ResultBlockResults, len(heights))
+	calls := make([]*rpcBatchCall, 0, 2*len(heights))
+	for i, num := range heights {
+		height := int64(num)
+		blocks[i] = &ctypes.ResultBlock{}
+		blockResults[i] = &ctypes.ResultBlockResults{}
+		calls = append(calls,
+			&rpcBatchCall{method: "block", params: map[string]any{"height": &height}, result: blocks[i]},
+			&rpcBatchCall{method: "block_results", params: map[string]any{"height": &height}, result: blockResults[i]},
+		)
+	}
+
+	b.logger.Info("fetching blocks batch from rpc", zap.Uint64("start_block_num", startBlockNum), zap.Uint64("end_block_num", heights[len(heights)-1]), zap.Int("block_count", len(heights)), zap.String("rpc_endpoint", wrappedClient.endpoint))
+	start := time.Now()
+	errs, err := wrappedClient.batchClient.send(ctx, calls)
+	if err != nil {
+		err = wrappedClient.redactError(err)
+		return nil, fmt.Errorf("sending batch for blocks %d to %d: %w", startBlockNum, heights[len(heights)-1], err)
+	}
+	// latency is recorded per block so batching endpoints compare fairly with the others
+	wrappedClient.stats.recordLatency(time.Since(start) / time.Duration(len(heights)))
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var first *fetchedBlock
+	for i, num := range heights {
+		fetched := &fetchedBlock{
+			block:              blocks[i],
+			blockClient:        wrappedClient,
+			blockResults:       blockResults[i],
+			blockResultsClient: wrappedClient,
+		}
+
+		err := errors.Join(errs[2*i], errs[2*i+1])
+		if err == nil {
+			err = fetched.validate(int64(num))
+		}
+		if err != nil {
+			b.logger.Debug("block left out of batch", zap.Uint64("block_num", num), zap.String("rpc_endpoint", wrappedClient.endpoint), zap.Error(err))
+			continue
+		}
+
+		switch {
+		case num == startBlockNum:
+			first = fetched
+		case num > b.passed:
+			b.blocks[num] = fetched
+		}
+	}
+	return first, nil
+}
@@ -0,0 +1,407 @@
+package v03811
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testRPCServer serves the `block`, `block_results` and `header` calls of a test chain storage,
+// batched or not. batchReply, when set, answers batches in place of the server, beforeReply, when
+// set, is called before answering a single call and fails it when it returns an error. The
+// handler does not assert anything, the calls it received are recorded for the test to check them.
+type testRPCServer struct {
+	*httptest.Server
+
+	storage     *testChainStorage
+	pruned      map[int64]bool
+	batchReply  func(w http.ResponseWriter)
+	beforeReply func(r *http.Request, request types.RPCRequest) error
+
+	batches atomic.Int64
+	singles atomic.Int64
+
+	lock      sync.Mutex
+	calls     []string
+	malformed []string
+}
+
+func newTestRPCServer(t *testing.T, storage *testChainStorage) *testRPCServer {
+	t.Helper()
+
+	s := &testRPCServer{storage: storage, pruned: map[int64]bool{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			s.reject(w, fmt.Sprintf("reading body: %s", err))
+			return
+		}
+
+		if !bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+			s.singles.Add(1)
+			var request types.RPCRequest
+			if err := json.Unmarshal(body, &request); err != nil {
+				s.reject(w, fmt.Sprintf("decoding request %q: %s", body, err))
+				return
+			}
+			if s.beforeReply != nil {
+				if err := s.beforeReply(r, request); err != nil {
+					_ = json.NewEncoder(w).Encode(types.RPCInternalError(request.ID, err))
+					return
+				}
+			}
+			_ = json.NewEncoder(w).Encode(s.reply(request))
+			return
+		}
+
+		s.batches.Add(1)
+		if s.batchReply != nil {
+			s.batchReply(w)
+			return
+		}
+
+		var requests []types.RPCRequest
+		if err := json.Unmarshal(body, &requests); err != nil {
+			s.reject(w, fmt.Sprintf("decoding batch %q: %s", body, err))
+			return
+		}
+		responses := make([]types.RPCResponse, len(requests))
+		for i, request := range requests {
+			responses[i] = s.reply(request)
+		}
+		_ = json.NewEncoder(w).Encode(responses)
+	}))
+	t.Cleanup(func() {
+		s.Close()
+		require.Empty(t, s.malformed, "malformed requests received by the test rpc server")
+	})
+
+	return s
+}
+
+func (s *testRPCServer) reject(w http.ResponseWriter, reason string) {
+	s.lock.Lock()
+	s.malformed = append(s.malformed, reason)
+	s.lock.Unlock()
+	w.WriteHeader(http.StatusBadRequest)
+}
+
+func (s *testRPCServer) reply(request types.RPCRequest) types.RPCResponse {
+	var params struct {
+		Height string `json:"height"`
+	}
+	if err := json.Unmarshal(request.Params, &params); err != nil {
+		return types.RPCInvalidParamsError(request.ID, err)
+	}
+	height, err := strconv.ParseInt(params.Height, 10, 64)
+	if err != nil {
+		return types.RPCInvalidParamsError(request.ID, err)
+	}
+
+	s.lock.Lock()
+	s.calls = append(s.calls, fmt.Sprintf("%s %d", request.Method, height))
+	s.lock.Unlock()
+
+	if s.pruned[height] || s.storage.blocks[height] == nil {
+		return types.RPCInternalError(request.ID, fmt.Errorf("height %d is not available", height))
+	}
+
+	response := s.storage.responses[height]
+	switch request.Method {
+	case "block":
+		return types.NewRPCSuccessResponse(request.ID, &ctypes.ResultBlock{BlockID: s.storage.blockIDs[height], Block: s.storage.blocks[height]})
+	case "block_results":
+		return types.NewRPCSuccessResponse(request.ID, &ctypes.ResultBlockResults{
+			Height:                height,
+			TxsResults:            response.TxResults,
+			FinalizeBlockEvents:   response.Events,
+			ValidatorUpdates:      response.ValidatorUpdates,
+			ConsensusParamUpdates: response.ConsensusParamUpdates,
+			AppHash:               response.AppHash,
+		})
+	case "header":
+		return types.NewRPCSuccessResponse(request.ID, &ctypes.ResultHeader{Header: &s.storage.blocks[height].Header})
+	}
+	return types.RPCMethodNotFoundError(request.ID)
+}
+
+// receivedCalls returns the `<method> <height>` calls received so far.
+func (s *testRPCServer) receivedCalls() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]string(nil), s.calls...)
+}
+
+func newTestClient(t *testing.T, server *testRPCServer) *CometHttpClientWrap {
+	t.Helper()
+
+	client, err := NewCometHttpClientWrap(server.URL, EndpointTierRecent, EndpointLimits{}, nil, zap.NewNop())
+	require.NoError(t, err)
+	return client
+}
+
+func TestBatchFetcher_Fetch(t *testing.T) {
+	storage := newTestChainStorage(t, 10, 20)
+	server := newTestRPCServer(t, storage)
+	server.pruned[12] = true
+	client := newTestClient(t, server)
+
+	batch := newBatchFetcher(5, zap.NewNop())
+	batch.requested(10)
+
+	fetched, err := batch.fetch(context.Background(), client, 10, 20)
+	require.NoError(t, err)
+	require.NotNil(t, fetched)
+	require.Equal(t, int64(10), fetched.block.Block.Height)
+	require.Equal(t, int64(1), server.batches.Load())
+	require.ElementsMatch(t, []string{
+		"block 10", "block_results 10", "block 11", "block_results 11", "block 12", "block_results 12",
+		"block 13", "block_results 13", "block 14", "block_results 14",
+	}, server.receivedCalls())
+
+	// the height the endpoint failed to serve is left out, the others are kept
+	require.Nil(t, batch.peek(10))
+	require.NotNil(t, batch.peek(11))
+	require.Nil(t, batch.peek(12))
+	require.NotNil(t, batch.peek(13))
+	require.NotNil(t, batch.peek(14))
+	require.Nil(t, batch.peek(15))
+
+	// a batch not holding the requested height is no error, the caller fetches it alone
+	fetched, err = batch.fetch(context.Background(), client, 12, 20)
+	require.NoError(t, err)
+	require.Nil(t, fetched)
+}
+
+func TestBatchFetcher_Reserve(t *testing.T) {
+	batch := newBatchFetcher(5, zap.NewNop())
+	batch.blocks[12] = &fetchedBlock{}
+
+	heights, done := batch.reserve(10, 100)
+	require.Equal(t, []uint64{10, 11, 13, 14}, heights)
+
+	// heights queued in another batch are skipped
+	heights, _ = batch.reserve(13, 100)
+	require.Nil(t, heights)
+	heights, other := batch.reserve(9, 100)
+	require.Equal(t, []uint64{9}, heights)
+	batch.release(heights, other)
+
+	heights, _ = batch.reserve(15, 17)
+	require.Equal(t, []uint64{15, 16, 17}, heights)
+
+	batch.release([]uint64{10, 11, 13, 14}, done)
+	heights, _ = batch.reserve(10, 11)
+	require.Equal(t, []uint64{10, 11}, heights)
+}
+
+func TestBatchFetcher_FetchWaitsForQueuedBlock(t *testing.T) {
+	storage := newTestChainStorage(t, 10, 20)
+	server := newTestRPCServer(t, storage)
+	client := newTestClient(t, server)
+
+	batch := newBatchFetcher(5, zap.NewNop())
+	batch.requested(10)
+
+	heights, done := batch.reserve(10, 20)
+	type result struct {
+		fetched *fetchedBlock
+		err     error
+	}
+	waited := make(chan result)
+	go func() {
+		fetched, err := batch.fetch(context.Background(), client, 12, 20)
+		waited <- result{fetched, err}
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	batch.lock.Lock()
+	batch.blocks[12] = &fetchedBlock{}
+	batch.lock.Unlock()
+	batch.release(heights, done)
+
+	got := <-waited
+	require.NoError(t, got.err)
+	require.NotNil(t, got.fetched)
+	require.Equal(t, int64(0), server.batches.Load())
+}
+
+func TestBatchFetcher_OutOfOrderTake(t *testing.T) {
+	batch := newBatchFetcher(10, zap.NewNop())
+	cache := func(heights ...uint64) {
+		for _, num := range heights {
+			batch.blocks[num] = &fetchedBlock{}
+		}
+	}
+	cached := func() (out []uint64) {
+		for num := uint64(0); num < 100; num++ {
+			if batch.peek(num) != nil {
+				out = append(out, num)
+			}
+		}
+		return out
+	}
+
+	// the workers of the poller ask for 10 to 14, 10 is the one fetching the batch
+	for num := uint64(10); num <= 14; num++ {
+		batch.requested(num)
+	}
+	cache(11, 12, 13, 14, 15, 16)
+
+	// 13 is handed over first, nothing can be evicted while 10 to 12 are still pending
+	require.NotNil(t, batch.take(13))
+	batch.handOver(13)
+	require.Equal(t, []uint64{11, 12, 14, 15, 16}, cached())
+
+	require.NotNil(t, batch.take(12))
+	batch.handOver(12)
+	batch.handOver(10)
+	require.Equal(t, []uint64{11, 14, 15, 16}, cached())
+
+	// once 11 is handed over, the poller moved past 13
+	require.NotNil(t, batch.take(11))
+	batch.handOver(11)
+	require.Equal(t, uint64(13), batch.passed)
+	require.Equal(t, []uint64{14, 15, 16}, cached())
+
+	// a block cached again below the watermark, say by a batch sent before the poller moved
+	// past it, is evicted with the next hand over
+	cache(12)
+	require.NotNil(t, batch.take(14))
+	batch.handOver(14)
+	require.Equal(t, uint64(14), batch.passed)
+	require.Equal(t, []uint64{15, 16}, cached())
+
+	// the poller going back, after a fork for instance, moves the watermark back
+	batch.requested(12)
+	require.Equal(t, uint64(11), batch.passed)
+	cache(12)
+	batch.handOver(12)
+	require.Equal(t, uint64(14), batch.passed)
+	require.Equal(t, []uint64{15, 16}, cached())
+}
+
+func TestRPCBlockFetcher_FetchWithBatch(t *testing.T) {
+	cases := []struct {
+		name         string
+		batchReply   func(w http.ResponseWriter)
+		pruned       []int64
+		wantErr      bool
+		wantRejected bool
+		wantPaused   bool
+		wantCached   bool
+	}{
+		{
+			name:       "batch served",
+			wantCached: true,
+		},
+		{
+			name:       "requested height failing",
+			pruned:     []int64{10},
+			wantErr:    true,
+			wantCached: true,
+		},
+		{
+			name:    "every call failing",
+			pruned:  []int64{10, 11, 12, 13, 14},
+			wantErr: true,
+		},
+		{
+			name: "batches not supported",
+			batchReply: func(w http.ResponseWriter) {
+				_ = json.NewEncoder(w).Encode(types.RPCInvalidRequestError(nil, fmt.Errorf("cannot unmarshal array into request")))
+			},
+			wantRejected: true,
+		},
+		{
+			name: "batches refused by a proxy",
+			batchReply: func(w http.ResponseWriter) {
+				_ = json.NewEncoder(w).Encode([]types.RPCResponse{types.RPCServerError(nil, fmt.Errorf("batch requests are not supported"))})
+			},
+			wantRejected: true,
+		},
+		{
+			name: "batch too large",
+			batchReply: func(w http.ResponseWriter) {
+				_ = json.NewEncoder(w).Encode([]types.RPCResponse{types.RPCInvalidRequestError(nil, fmt.Errorf("batch too large"))})
+			},
+			wantPaused: true,
+		},
+		{
+			name: "proxy error page",
+			batchReply: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte("<html><body>403 Forbidden</body></html>"))
+			},
+			wantPaused: true,
+		},
+		{
+			name: "rate limited",
+			batchReply: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			wantPaused: true,
+		},
+		{
+			name: "bad gateway",
+			batchReply: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusBadGateway)
+				_, _ = w.Write([]byte("<html><body>502 Bad Gateway</body></html>"))
+			},
+			wantPaused: true,
+		},
+		{
+			name: "truncated reply",
+			batchReply: func(w http.ResponseWriter) {
+				_, _ = w.Write([]byte(`[{"jsonrpc":"2.0","id":0,"result":`))
+			},
+			wantPaused: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			storage := newTestChainStorage(t, 10, 20)
+			server := newTestRPCServer(t, storage)
+			server.batchReply = c.batchReply
+			for _, height := range c.pruned {
+				server.pruned[height] = true
+			}
+			client := newTestClient(t, server)
+
+			headTracker := NewHeadTracker(nil, time.Second, time.Minute, nil, zap.NewNop())
+			headTracker.set(20)
+			fetcher := NewRPCFetcher(headTracker, nil, 5, zap.NewNop())
+
+			fetched, err := fetcher.fetchWithBatch(context.Background(), client, 10)
+			if c.wantErr {
+				// the single request falling back fails as well
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, int64(10), fetched.block.Block.Height)
+			}
+
+			require.Equal(t, int64(1), server.batches.Load())
+			require.Equal(t, c.wantRejected, fetcher.batch.rejectedEndpoints[client])
+			_, paused := fetcher.batch.pausedEndpoints[client]
+			require.Equal(t, c.wantPaused, paused)
+			require.Equal(t, !c.wantRejected && !c.wantPaused, fetcher.batch.enabled(client))
+			require.Equal(t, c.wantCached, fetcher.batch.peek(11) != nil)
+		})
+	}
+}
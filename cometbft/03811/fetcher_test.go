@@ -0,0 +1,374 @@
+package v03811
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	cometType "github.com/cometbft/cometbft/types"
+	"github.com/streamingfast/derr"
+	pbcosmos "github.com/streamingfast/firehose-cosmos/cosmos/pb/sf/cosmos/type/v2"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestConvertBlock_SplitEventsByMode(t *testing.T) {
+	storage := newTestChainStorage(t, 10, 10)
+	rpcBlock, rpcBlockResults := storage.rpcResponses(t, 10)
+
+	rpcBlockResults.FinalizeBlockEvents = []abci.Event{
+		{Type: "pre_block", Attributes: []abci.EventAttribute{{Key: "mode", Value: "PreBlock"}}},
+		{Type: "mint", Attributes: []abci.EventAttribute{{Key: "amount", Value: "10uatom"}, {Key: "mode", Value: "BeginBlock"}}},
+		{Type: "no_attribute"},
+		{Type: "no_mode", Attributes: []abci.EventAttribute{{Key: "action", Value: "BeginBlock"}}},
+		{Type: "complete_unbonding", Attributes: []abci.EventAttribute{{Key: "mode", Value: "EndBlock"}}},
+		{Type: "commission", Attributes: []abci.EventAttribute{{Key: "mode", Value: "BeginBlock"}}},
+	}
+
+	block, err := convertBlock(rpcBlock, rpcBlockResults, "rpc", ConversionOptions{}, zap.NewNop())
+	require.NoError(t, err)
+
+	eventTypes := func(events []*pbcosmos.Event) (out []string) {
+		for _, event := range events {
+			out = append(out, event.Type)
+		}
+		return out
+	}
+
+	// the combined list keeps every event in order, including the ones without a mode
+	require.Equal(t, []string{"pre_block", "mint", "no_attribute", "no_mode", "complete_unbonding", "commission"}, eventTypes(block.Events))
+	require.Equal(t, []string{"mint", "commission"}, eventTypes(block.BeginBlockEvents))
+	require.Equal(t, []string{"complete_unbonding"}, eventTypes(block.EndBlockEvents))
+
+	// split events are the same messages as the combined ones
+	require.Same(t, block.Events[1], block.BeginBlockEvents[0])
+	require.Same(t, block.Events[4], block.EndBlockEvents[0])
+}
+
+func TestConvertBlock_NoModeEvents(t *testing.T) {
+	storage := newTestChainStorage(t, 10, 10)
+	rpcBlock, rpcBlockResults := storage.rpcResponses(t, 10)
+	rpcBlockResults.FinalizeBlockEvents = []abci.Event{{Type: "transfer", Attributes: []abci.EventAttribute{{Key: "amount", Value: "1uatom"}}}}
+
+	block, err := convertBlock(rpcBlock, rpcBlockResults, "rpc", ConversionOptions{}, zap.NewNop())
+	require.NoError(t, err)
+	require.Len(t, block.Events, 1)
+	require.Nil(t, block.BeginBlockEvents)
+	require.Nil(t, block.EndBlockEvents)
+}
+
+func TestConvertBlock_AppHashAndConsensusParamUpdates(t *testing.T) {
+	storage := newTestChainStorage(t, 10, 10)
+	rpcBlock, rpcBlockResults := storage.rpcResponses(t, 10)
+	rpcBlockResults.ConsensusParamUpdates.Abci = &cmtproto.ABCIParams{VoteExtensionsEnableHeight: 12}
+	rpcBlockResults.ConsensusParamUpdates.Validator = &cmtproto.ValidatorParams{PubKeyTypes: []string{"ed25519"}}
+
+	block, err := convertBlock(rpcBlock, rpcBlockResults, "rpc", ConversionOptions{}, zap.NewNop())
+	require.NoError(t, err)
+
+	// the app hash of the finalize block response is the one resulting from this block, the
+	// header only has the one of the previous block
+	require.Equal(t, []byte(rpcBlockResults.AppHash), block.AppHash)
+	require.Equal(t, []byte(rpcBlock.Block.AppHash), block.Header.AppHash)
+	require.NotEqual(t, block.Header.AppHash, block.AppHash)
+
+	require.NotNil(t, block.ConsensusParamUpdates)
+	require.Equal(t, int64(22020096), block.ConsensusParamUpdates.Block.MaxBytes)
+	require.Equal(t, int64(-1), block.ConsensusParamUpdates.Block.MaxGas)
+	require.Equal(t, int64(12), block.ConsensusParamUpdates.Abci.VoteExtensionsEnableHeight)
+	require.Equal(t, []string{"ed25519"}, block.ConsensusParamUpdates.Validator.PubKeyTypes)
+	require.Nil(t, block.ConsensusParamUpdates.Evidence)
+	require.Nil(t, block.ConsensusParamUpdates.Version)
+}
+
+func TestConvertBlock_LastCommit(t *testing.T) {
+	storage := newTestChainStorage(t, 9, 10)
+	rpcBlock, rpcBlockResults := storage.rpcResponses(t, 10)
+
+	signedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	rpcBlock.Block.LastCommit.Round = 2
+	rpcBlock.Block.LastCommit.Signatures = []cometType.CommitSig{
+		{BlockIDFlag: cometType.BlockIDFlagCommit, ValidatorAddress: tmhash.SumTruncated([]byte("val-0")), Timestamp: signedAt, Signature: []byte("signature-0")},
+		cometType.NewCommitSigAbsent(),
+		{BlockIDFlag: cometType.BlockIDFlagNil, ValidatorAddress: tmhash.SumTruncated([]byte("val-2")), Timestamp: signedAt.Add(time.Second), Signature: []byte("signature-2")},
+	}
+
+	block, err := convertBlock(rpcBlock, rpcBlockResults, "rpc", ConversionOptions{}, zap.NewNop())
+	require.NoError(t, err)
+
+	lastCommit := rpcBlock.Block.LastCommit
+	require.NotNil(t, block.LastCommit)
+	require.Equal(t, int64(9), block.LastCommit.Height)
+	require.Equal(t, lastCommit.Height, block.LastCommit.Height)
+	require.Equal(t, int32(2), block.LastCommit.Round)
+	require.Equal(t, []byte(storage.blockIDs[9].Hash), block.LastCommit.BlockId.Hash)
+	require.Equal(t, lastCommit.BlockID.PartSetHeader.Total, block.LastCommit.BlockId.PartSetHeader.Total)
+	require.Equal(t, []byte(lastCommit.BlockID.PartSetHeader.Hash), block.LastCommit.BlockId.PartSetHeader.Hash)
+
+	require.Len(t, block.LastCommit.Signatures, 3)
+	wantFlags := []pbcosmos.BlockIDFlag{
+		pbcosmos.BlockIDFlag_BLOCK_ID_FLAG_COMMIT,
+		pbcosmos.BlockIDFlag_BLOCK_ID_FLAG_ABSENT,
+		pbcosmos.BlockIDFlag_BLOCK_ID_FLAG_NIL,
+	}
+	for i, sig := range block.LastCommit.Signatures {
+		require.Equal(t, wantFlags[i], sig.BlockIdFlag, "signature %d", i)
+		require.Equal(t, []byte(lastCommit.Signatures[i].ValidatorAddress), sig.ValidatorAddress, "signature %d", i)
+		require.Equal(t, lastCommit.Signatures[i].Signature, sig.Signature, "signature %d", i)
+	}
+	require.Equal(t, signedAt, block.LastCommit.Signatures[0].Timestamp.AsTime())
+	require.Equal(t, signedAt.Add(time.Second), block.LastCommit.Signatures[2].Timestamp.AsTime())
+	require.Empty(t, block.LastCommit.Signatures[1].ValidatorAddress)
+	require.Empty(t, block.LastCommit.Signatures[1].Signature)
+}
+
+func TestConvertBlock_NoLastCommitSignatures(t *testing.T) {
+	storage := newTestChainStorage(t, 10, 10)
+	rpcBlock, rpcBlockResults := storage.rpcResponses(t, 10)
+	rpcBlock.Block.LastCommit = nil
+
+	block, err := convertBlock(rpcBlock, rpcBlockResults, "rpc", ConversionOptions{}, zap.NewNop())
+	require.NoError(t, err)
+	require.Nil(t, block.LastCommit)
+}
+
+// awaitBothHalves makes the test rpc server hold the `block` and `block_results` calls until both
+// were received, so that sequential calls fail instead of being served.
+func awaitBothHalves(server *testRPCServer) {
+	var received atomic.Int32
+	bothReceived := make(chan struct{})
+	server.beforeReply = func(r *http.Request, request types.RPCRequest) error {
+		if received.Add(1) == 2 {
+			close(bothReceived)
+		}
+		select {
+		case <-bothReceived:
+			return nil
+		case <-time.After(5 * time.Second):
+			return fmt.Errorf("%s call was not sent concurrently with the other half of the block", request.Method)
+		}
+	}
+}
+
+func TestRPCBlockFetcher_FetchBothHalvesConcurrently(t *testing.T) {
+	storage := newTestChainStorage(t, 10, 10)
+	server := newTestRPCServer(t, storage)
+	awaitBothHalves(server)
+	client := newTestClient(t, server)
+
+	fetcher := NewRPCFetcher(nil, nil, 0, zap.NewNop())
+	fetched, err := fetcher.fetch(context.Background(), client, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), fetched.block.Block.Height)
+	require.Equal(t, int64(10), fetched.blockResults.Height)
+	require.Same(t, client, fetched.blockClient)
+	require.Same(t, client, fetched.blockResultsClient)
+	require.ElementsMatch(t, []string{"block 10", "block_results 10"}, server.receivedCalls())
+}
+
+func TestRPCBlockFetcher_FetchCancelsSiblingCall(t *testing.T) {
+	storage := newTestChainStorage(t, 10, 10)
+	server := newTestRPCServer(t, storage)
+	blockReceived := make(chan struct{})
+	blockCancelled := make(chan struct{})
+	server.beforeReply = func(r *http.Request, request types.RPCRequest) error {
+		if request.Method == "block" {
+			close(blockReceived)
+			select {
+			case <-r.Context().Done():
+				close(blockCancelled)
+				return r.Context().Err()
+			case <-time.After(5 * time.Second):
+				return nil
+			}
+		}
+
+		<-blockReceived
+		return errors.New("block results are not available")
+	}
+	client := newTestClient(t, server)
+
+	fetcher := NewRPCFetcher(nil, nil, 0, zap.NewNop())
+	_, err := fetcher.fetch(context.Background(), client, 10)
+	require.ErrorContains(t, err, "fetching block results 10 from rpc endpoint")
+	require.ErrorContains(t, err, "block results are not available")
+
+	select {
+	case <-blockCancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("block call was not cancelled")
+	}
+}
+
+func TestNewRPCFetcher_Options(t *testing.T) {
+	storage := newTestChainStorage(t, 10, 10)
+	server := newTestRPCServer(t, storage)
+	awaitBothHalves(server)
+	client := newTestClient(t, server)
+
+	headTracker := NewHeadTracker(nil, time.Second, time.Minute, nil, zap.NewNop())
+	router := NewEndpointRouter([]*CometHttpClientWrap{client}, headTracker, 0)
+	hedger := NewHedger(router, 0.95, time.Second, zap.NewNop())
+	verifier, err := NewVerifier(router, 2, 1, MismatchPolicyRefuse, time.Minute, zap.NewNop())
+	require.NoError(t, err)
+	checker, err := NewIntegrityChecker(headTracker, IntegrityPolicyFail, zap.NewNop())
+	require.NoError(t, err)
+	conversion := ConversionOptions{LosslessEventAttributes: true, DecodeTxs: true}
+
+	fetcher := NewRPCFetcher(headTracker, router, 5, zap.NewNop(),
+		WithHedging(hedger),
+		WithVerification(verifier),
+		WithIntegrityChecks(checker),
+		WithConversionOptions(conversion),
+	)
+	require.Same(t, hedger, fetcher.hedger)
+	require.Same(t, verifier, fetcher.verifier)
+	require.Same(t, checker, fetcher.integrity)
+	require.Same(t, fetcher.batch, checker.batch)
+	require.Equal(t, conversion, fetcher.conversion)
+
+	// hedged and witness fetches go through the fetcher, both halves of the block at once
+	for name, fetchSingle := range map[string]func(context.Context, *CometHttpClientWrap, uint64) (*fetchedBlock, error){
+		"hedger":   hedger.fetchSingle,
+		"verifier": verifier.fetchSingle,
+	} {
+		t.Run(name, func(t *testing.T) {
+			awaitBothHalves(server)
+			fetched, err := fetchSingle(context.Background(), client, 10)
+			require.NoError(t, err)
+			require.Same(t, client, fetched.blockClient)
+			require.Same(t, client, fetched.blockResultsClient)
+		})
+	}
+}
+
+func newTestFetcher(t *testing.T, server *testRPCServer) (*RPCBlockFetcher, *CometHttpClientWrap) {
+	t.Helper()
+
+	client := newTestClient(t, server)
+	client.earliestBlockHeight.Store(10)
+	client.latestBlockHeight.Store(20)
+
+	headTracker := NewHeadTracker(nil, time.Second, time.Minute, nil, zap.NewNop())
+	headTracker.set(20)
+	return NewRPCFetcher(headTracker, NewEndpointRouter([]*CometHttpClientWrap{client}, headTracker, 0), 0, zap.NewNop()), client
+}
+
+func TestRPCBlockFetcher_ShutdownDrainsInFlightFetches(t *testing.T) {
+	storage := newTestChainStorage(t, 10, 20)
+	server := newTestRPCServer(t, storage)
+	var once sync.Once
+	requestSent := make(chan struct{})
+	server.beforeReply = func(r *http.Request, request types.RPCRequest) error {
+		once.Do(func() { close(requestSent) })
+		select {
+		case <-r.Context().Done():
+			return r.Context().Err()
+		case <-time.After(5 * time.Second):
+			return nil
+		}
+	}
+	fetcher, client := newTestFetcher(t, server)
+
+	fetchErr := make(chan error, 1)
+	go func() {
+		_, _, err := fetcher.Fetch(context.Background(), client, 15)
+		fetchErr <- err
+	}()
+
+	select {
+	case <-requestSent:
+	case <-time.After(5 * time.Second):
+		t.Fatal("block was not requested")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, fetcher.Drain(ctx), context.DeadlineExceeded, "the fetch is still in flight")
+
+	start := time.Now()
+	fetcher.Shutdown()
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, fetcher.Drain(ctx))
+	require.Less(t, time.Since(start), 5*time.Second, "the in-flight request was cancelled")
+
+	err := <-fetchErr
+	var fatalErr *derr.FatalError
+	require.ErrorAs(t, err, &fatalErr)
+	require.ErrorIs(t, err, ErrFetcherShuttingDown)
+
+	// fetches are refused from then on, without reaching the endpoint
+	calls := len(server.receivedCalls())
+	_, _, err = fetcher.Fetch(context.Background(), client, 16)
+	require.ErrorAs(t, err, &fatalErr)
+	require.ErrorIs(t, err, ErrFetcherShuttingDown)
+	require.Len(t, server.receivedCalls(), calls)
+}
+
+func TestRPCBlockFetcher_FetchWaitIsCancellable(t *testing.T) {
+	storage := newTestChainStorage(t, 10, 20)
+	server := newTestRPCServer(t, storage)
+	fetcher, client := newTestFetcher(t, server)
+
+	// the block is above the head, the fetch waits for it until its context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, _, err := fetcher.Fetch(ctx, client, 30)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NotErrorIs(t, err, ErrFetcherShuttingDown)
+	require.Empty(t, server.receivedCalls())
+
+	b, _, err := fetcher.Fetch(context.Background(), client, 15)
+	require.NoError(t, err)
+	require.Equal(t, uint64(15), b.Number)
+	require.NoError(t, fetcher.Drain(context.Background()))
+}
+
+func TestRPCBlockFetcher_CancelledFetchIsNotScored(t *testing.T) {
+	storage := newTestChainStorage(t, 10, 20)
+	server := newTestRPCServer(t, storage)
+	server.pruned[16] = true
+	var once sync.Once
+	requestSent := make(chan struct{})
+	server.beforeReply = func(r *http.Request, request types.RPCRequest) error {
+		if request.Method == "block" && r.Context().Err() == nil {
+			once.Do(func() { close(requestSent) })
+		}
+		select {
+		case <-r.Context().Done():
+			return r.Context().Err()
+		case <-time.After(100 * time.Millisecond):
+			return nil
+		}
+	}
+	fetcher, client := newTestFetcher(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-requestSent
+		cancel()
+	}()
+	_, _, err := fetcher.Fetch(ctx, client, 15)
+	require.ErrorIs(t, err, context.Canceled)
+	_, errorRate, _ := client.stats.snapshot()
+	require.Zero(t, errorRate)
+	require.Zero(t, client.consecutiveFailures.Load())
+
+	// an endpoint failing on its own is scored
+	_, _, err = fetcher.Fetch(context.Background(), client, 16)
+	require.ErrorContains(t, err, "height 16 is not available")
+	_, errorRate, _ = client.stats.snapshot()
+	require.NotZero(t, errorRate)
+	require.Equal(t, int64(1), client.consecutiveFailures.Load())
+}
@@ -0,0 +1,181 @@
+package v03811
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const headFetchTimeout = 10 * time.Second
+
+// HeadTracker keeps the latest block number known for a set of clients. A single background
+// refresher polls the head (or reads it from the websocket watcher when one is fresh), readers
+// get it atomically and fetches waiting for a block are blocked until it is reached. The same
+// refresher periodically updates the block range held by each client from its `/status`.
+type HeadTracker struct {
+	clients               []*CometHttpClientWrap
+	refreshInterval       time.Duration
+	statusRefreshInterval time.Duration
+	headWatcher           *WebsocketHeadWatcher
+	logger                *zap.Logger
+
+	latest            atomic.Uint64
+	statusRefreshedAt time.Time
+	// highest latest height reported by the endpoints in the last status refresh
+	latestReported atomic.Uint64
+
+	lock    sync.Mutex
+	updated chan struct{}
+}
+
+// NewHeadTracker creates a tracker for the given clients, headWatcher is optional.
+func NewHeadTracker(clients []*CometHttpClientWrap, refreshInterval time.Duration, statusRefreshInterval time.Duration, headWatcher *WebsocketHeadWatcher, logger *zap.Logger) *HeadTracker {
+	return &HeadTracker{
+		clients:               clients,
+		refreshInterval:       refreshInterval,
+		statusRefreshInterval: statusRefreshInterval,
+		headWatcher:           headWatcher,
+		logger:                logger,
+		updated:               make(chan struct{}),
+	}
+}
+
+// Run refreshes the head until the context is cancelled.
+func (t *HeadTracker) Run(ctx context.Context) {
+	for {
+		if time.Since(t.statusRefreshedAt) >= t.statusRefreshInterval {
+			t.refreshStatuses(ctx)
+			t.statusRefreshedAt = time.Now()
+		}
+
+		t.refresh(ctx)
+
+		var headWatcherUpdated <-chan struct{}
+		if t.headWatcher != nil {
+			_, _, headWatcherUpdated = t.headWatcher.Latest()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-headWatcherUpdated:
+		case <-time.After(t.refreshInterval):
+		}
+	}
+}
+
+func (t *HeadTracker) refresh(ctx context.Context) {
+	if t.headWatcher != nil {
+		if latest, fresh, _ := t.headWatcher.Latest(); fresh {
+			t.set(latest)
+			return
+		}
+	}
+
+	for _, client := range t.clients {
+		callCtx, cancel := context.WithTimeout(ctx, headFetchTimeout)
+		latest, err := fetchLatestBlockNum(callCtx, client)
+		cancel()
+		if err != nil {
+			t.logger.Warn("failed to fetch latest block num", zap.String("rpc_endpoint", client.endpoint), zap.Error(err))
+			continue
+		}
+
+		client.observeLatest(int64(latest))
+		t.set(latest)
+		return
+	}
+}
+
+func (t *HeadTracker) refreshStatuses(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, client := range t.clients {
+		wg.Add(1)
+		go func(client *CometHttpClientWrap) {
+			defer wg.Done()
+
+			callCtx, cancel := context.WithTimeout(ctx, headFetchTimeout)
+			defer cancel()
+
+			if err := client.UpdateStatus(callCtx); err != nil {
+				t.logger.Warn("failed to refresh endpoint status", zap.String("rpc_endpoint", client.endpoint), zap.Error(err))
+				return
+			}
+
+			earliest, latest := client.BlockRange()
+			t.logger.Debug("refreshed endpoint status", zap.String("rpc_endpoint", client.endpoint), zap.Int64("earliest_block_height", earliest), zap.Int64("latest_block_height", latest))
+		}(client)
+	}
+	wg.Wait()
+
+	var latestReported uint64
+	for _, client := range t.clients {
+		if _, latest := client.BlockRange(); latest > 0 {
+			latestReported = max(latestReported, uint64(latest))
+		}
+	}
+	if latestReported > 0 {
+		t.latestReported.Store(latestReported)
+		t.set(latestReported)
+	}
+}
+
+func (t *HeadTracker) set(blockNum uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if blockNum <= t.latest.Load() {
+		return
+	}
+
+	t.latest.Store(blockNum)
+	headBlockNumber.SetUint64(blockNum)
+
+	close(t.updated)
+	t.updated = make(chan struct{})
+}
+
+// LatestReportedBlockNum returns the highest latest height reported by the endpoints in the last
+// status refresh, 0 if statuses were never fetched. Unlike LatestBlockNum it is as old as the
+// endpoint block ranges, so lags computed against it are not inflated by a fresher head.
+func (t *HeadTracker) LatestReportedBlockNum() uint64 {
+	return t.latestReported.Load()
+}
+
+// LatestBlockNum returns the latest known head, 0 if it was never fetched.
+func (t *HeadTracker) LatestBlockNum() uint64 {
+	return t.latest.Load()
+}
+
+// WaitFor blocks until the head reaches blockNum or the context is done, it returns the head.
+func (t *HeadTracker) WaitFor(ctx context.Context, blockNum uint64) (uint64, error) {
+	for {
+		t.lock.Lock()
+		latest := t.latest.Load()
+		updated := t.updated
+		t.lock.Unlock()
+
+		if latest >= blockNum {
+			return latest, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return latest, fmt.Errorf("waiting for block %d, head is at %d: %w", blockNum, latest, ctx.Err())
+		case <-updated:
+		}
+	}
+}
+
+func fetchLatestBlockNum(ctx context.Context, client *CometHttpClientWrap) (uint64, error) {
+	resultChainInfo, err := client.cometHttpClient.BlockchainInfo(ctx, 0, 0)
+	if err != nil {
+		err = client.redactError(err)
+		return 0, err
+	}
+	return uint64(resultChainInfo.LastHeight), nil
+}
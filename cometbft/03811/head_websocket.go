@@ -0,0 +1,205 @@
+package v03811
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	cometType "github.com/cometbft/cometbft/types"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+// websocketSubscribeID is the id of the subscribe request, events are sent with another id.
+const websocketSubscribeID = types.JSONRPCIntID(1)
+
+// WebsocketHeadWatcher follows the chain head through a `tm.event='NewBlockHeader'` subscription
+// on a cometbft websocket. The head it reports is only trusted while headers keep flowing, the
+// fetcher falls back to polling `BlockchainInfo` as soon as the websocket goes quiet.
+type WebsocketHeadWatcher struct {
+	// endpoint is redacted, it is safe to log
+	endpoint       string
+	rawEndpoint    string
+	address        string
+	headers        http.Header
+	dialer         *websocket.Dialer
+	staleAfter     time.Duration
+	reconnectDelay time.Duration
+	logger         *zap.Logger
+
+	lock       sync.Mutex
+	latest     uint64
+	receivedAt time.Time
+	updated    chan struct{}
+}
+
+// NewWebsocketHeadWatcher creates a watcher subscribing on the `/websocket` path of the rpc
+// endpoint, auth is optional and holds the same credentials as for the rpc clients.
+func NewWebsocketHeadWatcher(endpoint string, auth *EndpointAuth, staleAfter time.Duration, logger *zap.Logger) (*WebsocketHeadWatcher, error) {
+	u, err := parseEndpoint(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint: %w", err)
+	}
+
+	w := &WebsocketHeadWatcher{
+		endpoint:       RedactEndpoint(endpoint),
+		rawEndpoint:    endpoint,
+		headers:        http.Header{},
+		dialer:         &websocket.Dialer{Proxy: http.ProxyFromEnvironment, HandshakeTimeout: staleAfter},
+		staleAfter:     staleAfter,
+		reconnectDelay: time.Second,
+		logger:         logger,
+		updated:        make(chan struct{}),
+	}
+
+	switch u.Scheme {
+	case "https", "wss":
+		u.Scheme = "wss"
+	case "unix":
+		socket := u.Path
+		w.dialer.NetDial = func(string, string) (net.Conn, error) {
+			return net.Dial("unix", socket)
+		}
+		u.Scheme, u.Host, u.Path, u.RawPath = "ws", "localhost", "", ""
+	default:
+		u.Scheme = "ws"
+	}
+
+	if u.User != nil {
+		password, _ := u.User.Password()
+		w.headers.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(u.User.Username()+":"+password)))
+		u.User = nil
+	}
+
+	if auth != nil {
+		headers, tlsConfig, err := auth.resolve()
+		if err != nil {
+			return nil, fmt.Errorf("configuring auth: %w", err)
+		}
+		for name, values := range headers {
+			w.headers[name] = values
+		}
+		w.dialer.TLSClientConfig = tlsConfig
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/websocket"
+	u.RawPath = ""
+	w.address = u.String()
+
+	return w, nil
+}
+
+// Run keeps a subscription open until the context is cancelled, reconnecting whenever the
+// websocket drops or stops delivering headers.
+func (w *WebsocketHeadWatcher) Run(ctx context.Context) {
+	for {
+		err := w.subscribe(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		w.logger.Warn("websocket head subscription ended, falling back to polling until reconnected", zap.String("ws_endpoint", w.endpoint), zap.Error(redactEndpointError(err, w.rawEndpoint)))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.reconnectDelay):
+		}
+	}
+}
+
+func (w *WebsocketHeadWatcher) subscribe(ctx context.Context) error {
+	conn, resp, err := w.dialer.DialContext(ctx, w.address, w.headers)
+	if err != nil {
+		if resp != nil {
+			return fmt.Errorf("dialing websocket: %w (http status %d)", err, resp.StatusCode)
+		}
+		return fmt.Errorf("dialing websocket: %w", err)
+	}
+	defer conn.Close()
+
+	// closing the connection unblocks the read below
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	defer stop()
+
+	request, err := types.MapToRequest(websocketSubscribeID, "subscribe", map[string]any{"query": cometType.EventQueryNewBlockHeader.String()})
+	if err != nil {
+		return fmt.Errorf("encoding subscribe request: %w", err)
+	}
+	if err := conn.WriteJSON(request); err != nil {
+		return fmt.Errorf("sending subscribe request: %w", err)
+	}
+
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(w.staleAfter)); err != nil {
+			return fmt.Errorf("setting read deadline: %w", err)
+		}
+
+		var response types.RPCResponse
+		if err := conn.ReadJSON(&response); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				return fmt.Errorf("no new block header received for %s", w.staleAfter)
+			}
+			return fmt.Errorf("reading websocket: %w", err)
+		}
+
+		if response.Error != nil {
+			return fmt.Errorf("subscribing to new block headers: %w", response.Error)
+		}
+		if response.ID == websocketSubscribeID {
+			w.logger.Info("subscribed to new block headers", zap.String("ws_endpoint", w.endpoint))
+			continue
+		}
+
+		event := &ctypes.ResultEvent{}
+		if err := cmtjson.Unmarshal(response.Result, event); err != nil {
+			w.logger.Warn("failed to decode websocket event", zap.Error(err))
+			continue
+		}
+		data, ok := event.Data.(cometType.EventDataNewBlockHeader)
+		if !ok {
+			w.logger.Warn("unexpected websocket event data", zap.String("type", fmt.Sprintf("%T", event.Data)))
+			continue
+		}
+		w.setLatest(uint64(data.Header.Height))
+	}
+}
+
+func (w *WebsocketHeadWatcher) setLatest(blockNum uint64) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.receivedAt = time.Now()
+	if blockNum <= w.latest {
+		return
+	}
+
+	w.latest = blockNum
+	close(w.updated)
+	w.updated = make(chan struct{})
+}
+
+// Latest returns the last head seen on the websocket and whether it is still fresh enough to be
+// trusted. The returned channel is closed on the next head update.
+func (w *WebsocketHeadWatcher) Latest() (uint64, bool, <-chan struct{}) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	fresh := !w.receivedAt.IsZero() && time.Since(w.receivedAt) < w.staleAfter
+	return w.latest, fresh, w.updated
+}
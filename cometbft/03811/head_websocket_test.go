@@ -0,0 +1,214 @@
+package v03811
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	cometType "github.com/cometbft/cometbft/types"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testWebsocketServer answers the `NewBlockHeader` subscription of each connection with the
+// headers returned by headersFor, then stays quiet until the watcher goes away. The handler does
+// not assert anything, failures are recorded for the test to check them.
+type testWebsocketServer struct {
+	*httptest.Server
+
+	connections atomic.Int32
+
+	lock     sync.Mutex
+	failures []string
+}
+
+func newTestWebsocketServer(t *testing.T, path string, headersFor func(connection int32) []int64) *testWebsocketServer {
+	t.Helper()
+
+	s := &testWebsocketServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path || r.Header.Get("X-Api-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			s.fail("upgrading connection: %s", err)
+			return
+		}
+		defer conn.Close()
+		connection := s.connections.Add(1)
+
+		var request types.RPCRequest
+		if err := conn.ReadJSON(&request); err != nil {
+			s.fail("reading subscribe request: %s", err)
+			return
+		}
+		if request.Method != "subscribe" {
+			s.fail("unexpected method %q", request.Method)
+			return
+		}
+		if err := conn.WriteJSON(types.NewRPCSuccessResponse(request.ID, &ctypes.ResultSubscribe{})); err != nil {
+			s.fail("writing subscribe response: %s", err)
+			return
+		}
+
+		for _, height := range headersFor(connection) {
+			event := &ctypes.ResultEvent{
+				Query: cometType.EventQueryNewBlockHeader.String(),
+				Data:  cometType.EventDataNewBlockHeader{Header: cometType.Header{Height: height}},
+			}
+			if err := conn.WriteJSON(types.NewRPCSuccessResponse(types.JSONRPCStringID("1#event"), event)); err != nil {
+				s.fail("writing event: %s", err)
+				return
+			}
+		}
+
+		// keeps the connection open until the watcher goes away
+		_, _, _ = conn.ReadMessage()
+	}))
+	t.Cleanup(func() {
+		s.Close()
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		require.Empty(t, s.failures, "failures of the test websocket server")
+	})
+
+	return s
+}
+
+func (s *testWebsocketServer) fail(format string, args ...any) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.failures = append(s.failures, fmt.Sprintf(format, args...))
+}
+
+func (s *testWebsocketServer) endpoint() string {
+	return strings.Replace(s.URL, "http://", "tcp://", 1) + "/0123456789abcdef"
+}
+
+func newTestWebsocketHeadWatcher(t *testing.T, server *testWebsocketServer, staleAfter time.Duration) *WebsocketHeadWatcher {
+	t.Helper()
+
+	watcher, err := NewWebsocketHeadWatcher(server.endpoint(), &EndpointAuth{Headers: map[string]string{"X-Api-Key": "secret"}}, staleAfter, zap.NewNop())
+	require.NoError(t, err)
+	watcher.reconnectDelay = 10 * time.Millisecond
+	return watcher
+}
+
+func TestWebsocketHeadWatcher_Run(t *testing.T) {
+	server := newTestWebsocketServer(t, "/0123456789abcdef/websocket", func(int32) []int64 { return []int64{41, 42} })
+
+	watcher := newTestWebsocketHeadWatcher(t, server, 10*time.Second)
+	require.Equal(t, "tcp://"+strings.TrimPrefix(server.URL, "http://")+"/redacted", watcher.endpoint)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Run(ctx)
+
+	require.Eventually(t, func() bool {
+		latest, fresh, _ := watcher.Latest()
+		return latest == 42 && fresh
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, int32(1), server.connections.Load())
+}
+
+func TestWebsocketHeadWatcher_ReconnectsWhenStale(t *testing.T) {
+	// the second connection goes quiet right after subscribing
+	server := newTestWebsocketServer(t, "/0123456789abcdef/websocket", func(connection int32) []int64 {
+		switch connection {
+		case 1:
+			return []int64{10}
+		case 2:
+			return nil
+		}
+		return []int64{30}
+	})
+
+	watcher := newTestWebsocketHeadWatcher(t, server, 200*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Run(ctx)
+
+	require.Eventually(t, func() bool {
+		latest, fresh, _ := watcher.Latest()
+		return server.connections.Load() == 2 && latest == 10 && !fresh
+	}, 5*time.Second, 5*time.Millisecond, "the quiet subscription is not trusted")
+
+	require.Eventually(t, func() bool {
+		latest, fresh, _ := watcher.Latest()
+		return server.connections.Load() >= 3 && latest == 30 && fresh
+	}, 5*time.Second, 5*time.Millisecond, "the stale subscription is restarted")
+}
+
+// newTestBlockchainInfoServer answers the `blockchain` calls the head tracker polls with the given
+// last height, any other call fails.
+func newTestBlockchainInfoServer(t *testing.T, lastHeight int64, polls *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var request types.RPCRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if request.Method != "blockchain" {
+			_ = json.NewEncoder(w).Encode(types.RPCMethodNotFoundError(request.ID))
+			return
+		}
+		polls.Add(1)
+		_ = json.NewEncoder(w).Encode(types.NewRPCSuccessResponse(request.ID, &ctypes.ResultBlockchainInfo{LastHeight: lastHeight}))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHeadTracker_FallsBackToPollingWhenWebsocketIsStale(t *testing.T) {
+	// headers only flow on the first connection, the subscription stays quiet afterwards
+	server := newTestWebsocketServer(t, "/0123456789abcdef/websocket", func(connection int32) []int64 {
+		if connection == 1 {
+			return []int64{100}
+		}
+		return nil
+	})
+	watcher := newTestWebsocketHeadWatcher(t, server, 500*time.Millisecond)
+
+	var polls atomic.Int32
+	rpcServer := newTestBlockchainInfoServer(t, 150, &polls)
+	client, err := NewCometHttpClientWrap(rpcServer.URL, EndpointTierRecent, EndpointLimits{}, nil, zap.NewNop())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Run(ctx)
+
+	require.Eventually(t, func() bool {
+		_, fresh, _ := watcher.Latest()
+		return fresh
+	}, 5*time.Second, 5*time.Millisecond)
+
+	headTracker := NewHeadTracker([]*CometHttpClientWrap{client}, 20*time.Millisecond, time.Hour, watcher, zap.NewNop())
+	go headTracker.Run(ctx)
+
+	require.Eventually(t, func() bool { return headTracker.LatestBlockNum() == 100 }, 5*time.Second, 5*time.Millisecond)
+	require.Zero(t, polls.Load(), "the head is not polled while the websocket is fresh")
+
+	require.Eventually(t, func() bool { return headTracker.LatestBlockNum() == 150 }, 5*time.Second, 5*time.Millisecond)
+	require.NotZero(t, polls.Load())
+	_, latest := client.BlockRange()
+	require.Equal(t, int64(150), latest)
+}
@@ -0,0 +1,148 @@
+package v03811
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	hedgeLatencySamples    = 256
+	hedgeMinLatencySamples = 20
+)
+
+// Hedger sends a single block fetch to a second endpoint when the first one did not answer within
+// the configured percentile of recently observed fetch latencies. Hedging only kicks in once
+// enough latencies were observed, and never sooner than the minimum delay.
+type Hedger struct {
+	router     *EndpointRouter
+	percentile float64
+	minDelay   time.Duration
+	logger     *zap.Logger
+
+	// set by WithHedging, fetches the block from a single endpoint without batching
+	fetchSingle func(ctx context.Context, wrappedClient *CometHttpClientWrap, requestBlockNum uint64) (*fetchedBlock, error)
+
+	lock      sync.Mutex
+	latencies []time.Duration
+	next      int
+}
+
+func NewHedger(router *EndpointRouter, percentile float64, minDelay time.Duration, logger *zap.Logger) *Hedger {
+	return &Hedger{
+		router:     router,
+		percentile: percentile,
+		minDelay:   minDelay,
+		logger:     logger,
+	}
+}
+
+func (h *Hedger) observe(latency time.Duration) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if len(h.latencies) < hedgeLatencySamples {
+		h.latencies = append(h.latencies, latency)
+		return
+	}
+	h.latencies[h.next] = latency
+	h.next = (h.next + 1) % hedgeLatencySamples
+}
+
+// delay returns how long to wait on the primary endpoint before hedging, 0 when there are not
+// enough samples to hedge yet.
+func (h *Hedger) delay() time.Duration {
+	h.lock.Lock()
+	if len(h.latencies) < hedgeMinLatencySamples {
+		h.lock.Unlock()
+		return 0
+	}
+	sorted := make([]time.Duration, len(h.latencies))
+	copy(sorted, h.latencies)
+	h.lock.Unlock()
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	delay := sorted[int(h.percentile*float64(len(sorted)-1))]
+	if delay < h.minDelay {
+		return h.minDelay
+	}
+	return delay
+}
+
+type hedgeResult struct {
+	fetched *fetchedBlock
+	err     error
+	client  *CometHttpClientWrap
+	hedge   bool
+}
+
+func (h *Hedger) fetch(ctx context.Context, primary *CometHttpClientWrap, requestBlockNum uint64) (*fetchedBlock, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	start := time.Now()
+	results := make(chan hedgeResult, 2)
+	go func() {
+		fetched, err := h.fetchSingle(ctx, primary, requestBlockNum)
+		results <- hedgeResult{fetched: fetched, err: err, client: primary}
+	}()
+
+	var hedgeTimer <-chan time.Time
+	if delay := h.delay(); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		hedgeTimer = timer.C
+	}
+
+	pending := 1
+	hedged := false
+	var errs error
+	for pending > 0 {
+		select {
+		case <-hedgeTimer:
+			hedgeTimer = nil
+			secondary := h.router.Alternative(primary, requestBlockNum)
+			if secondary == nil {
+				continue
+			}
+
+			h.logger.Info("primary endpoint is slow, hedging block fetch", zap.Uint64("block_num", requestBlockNum), zap.String("rpc_endpoint", primary.endpoint), zap.String("hedge_rpc_endpoint", secondary.endpoint))
+			hedgedRequests.Inc()
+			hedged = true
+			pending++
+			go func() {
+				fetched, err := h.fetchSingle(ctx, secondary, requestBlockNum)
+				results <- hedgeResult{fetched: fetched, err: err, client: secondary, hedge: true}
+			}()
+
+		case result := <-results:
+			pending--
+			if result.err == nil {
+				h.observe(time.Since(start))
+				if pending > 0 {
+					hedgedRequestsWasted.Inc()
+				}
+				if result.hedge {
+					hedgedRequestsWon.Inc()
+					result.client.recordSuccess()
+				}
+				return result.fetched, nil
+			}
+
+			errs = errors.Join(errs, result.err)
+			if result.hedge {
+				if ctx.Err() == nil {
+					result.client.recordFailure()
+				}
+			} else if !hedged {
+				return nil, result.err
+			}
+		}
+	}
+
+	return nil, errs
+}
@@ -0,0 +1,178 @@
+package v03811
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestHedger_Delay(t *testing.T) {
+	hedger := NewHedger(nil, 0.95, 0, zap.NewNop())
+
+	for i := 1; i < hedgeMinLatencySamples; i++ {
+		hedger.observe(time.Second)
+	}
+	require.Zero(t, hedger.delay(), "not enough samples to hedge")
+
+	hedger = NewHedger(nil, 0.95, 0, zap.NewNop())
+	for i := 100; i >= 1; i-- {
+		hedger.observe(time.Duration(i) * time.Millisecond)
+	}
+	require.Equal(t, 95*time.Millisecond, hedger.delay())
+
+	hedger.minDelay = 200 * time.Millisecond
+	require.Equal(t, 200*time.Millisecond, hedger.delay())
+
+	// only the last samples of the window are kept
+	hedger = NewHedger(nil, 0.5, 0, zap.NewNop())
+	for i := 0; i < hedgeLatencySamples; i++ {
+		hedger.observe(time.Second)
+	}
+	require.Equal(t, time.Second, hedger.delay())
+	for i := 0; i < hedgeLatencySamples/2+1; i++ {
+		hedger.observe(10 * time.Millisecond)
+	}
+	require.Len(t, hedger.latencies, hedgeLatencySamples)
+	require.Equal(t, 10*time.Millisecond, hedger.delay())
+}
+
+// fakeEndpoint answers a block fetch after its latency, or gives up when its fetch is cancelled.
+type fakeEndpoint struct {
+	client    *CometHttpClientWrap
+	latency   time.Duration
+	err       error
+	calls     atomic.Int32
+	startedAt atomic.Int64
+	cancelled chan struct{}
+}
+
+func newFakeEndpoint(t *testing.T, endpoint string, latency time.Duration) *fakeEndpoint {
+	t.Helper()
+
+	client, err := NewCometHttpClientWrap(endpoint, EndpointTierRecent, EndpointLimits{}, nil, zap.NewNop())
+	require.NoError(t, err)
+	client.earliestBlockHeight.Store(1)
+	client.latestBlockHeight.Store(1000)
+	return &fakeEndpoint{client: client, latency: latency, cancelled: make(chan struct{})}
+}
+
+func (e *fakeEndpoint) fetch(ctx context.Context) (*fetchedBlock, error) {
+	e.calls.Add(1)
+	e.startedAt.Store(time.Now().UnixNano())
+
+	select {
+	case <-time.After(e.latency):
+		if e.err != nil {
+			return nil, e.err
+		}
+		return &fetchedBlock{blockClient: e.client, blockResultsClient: e.client}, nil
+	case <-ctx.Done():
+		close(e.cancelled)
+		return nil, ctx.Err()
+	}
+}
+
+func newTestHedger(t *testing.T, minDelay time.Duration, endpoints ...*fakeEndpoint) *Hedger {
+	t.Helper()
+
+	clients := make([]*CometHttpClientWrap, len(endpoints))
+	for i, endpoint := range endpoints {
+		clients[i] = endpoint.client
+	}
+	headTracker := NewHeadTracker(nil, time.Second, time.Minute, nil, zap.NewNop())
+	headTracker.set(1000)
+
+	hedger := NewHedger(NewEndpointRouter(clients, headTracker, 0), 0.95, minDelay, zap.NewNop())
+	hedger.fetchSingle = func(ctx context.Context, wrappedClient *CometHttpClientWrap, requestBlockNum uint64) (*fetchedBlock, error) {
+		for _, endpoint := range endpoints {
+			if endpoint.client == wrappedClient {
+				return endpoint.fetch(ctx)
+			}
+		}
+		t.Errorf("unexpected endpoint %q", wrappedClient.endpoint)
+		return nil, errors.New("unexpected endpoint")
+	}
+	for i := 0; i < hedgeMinLatencySamples; i++ {
+		hedger.observe(10 * time.Millisecond)
+	}
+	return hedger
+}
+
+func requireCancelled(t *testing.T, endpoint *fakeEndpoint) {
+	t.Helper()
+
+	select {
+	case <-endpoint.cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("fetch from %q was not cancelled", endpoint.client.endpoint)
+	}
+}
+
+func TestHedger_SlowPrimaryIsHedged(t *testing.T) {
+	slow := newFakeEndpoint(t, "http://slow:26657", time.Hour)
+	fast := newFakeEndpoint(t, "http://fast:26657", 0)
+	hedger := newTestHedger(t, 100*time.Millisecond, slow, fast)
+
+	start := time.Now()
+	fetched, err := hedger.fetch(context.Background(), slow.client, 100)
+	require.NoError(t, err)
+	require.Same(t, fast.client, fetched.blockClient)
+
+	// the hedge waited for the delay, which is the minimum one as observed latencies are lower
+	require.Equal(t, int32(1), fast.calls.Load())
+	require.GreaterOrEqual(t, time.Duration(fast.startedAt.Load()-start.UnixNano()), 100*time.Millisecond)
+
+	requireCancelled(t, slow)
+}
+
+func TestHedger_PrimaryWinsAfterHedging(t *testing.T) {
+	primary := newFakeEndpoint(t, "http://primary:26657", 150*time.Millisecond)
+	hedge := newFakeEndpoint(t, "http://hedge:26657", time.Hour)
+	hedger := newTestHedger(t, 50*time.Millisecond, primary, hedge)
+
+	fetched, err := hedger.fetch(context.Background(), primary.client, 100)
+	require.NoError(t, err)
+	require.Same(t, primary.client, fetched.blockClient)
+	require.Equal(t, int32(1), hedge.calls.Load())
+
+	requireCancelled(t, hedge)
+}
+
+func TestHedger_FastPrimaryIsNotHedged(t *testing.T) {
+	primary := newFakeEndpoint(t, "http://primary:26657", 0)
+	secondary := newFakeEndpoint(t, "http://secondary:26657", 0)
+	hedger := newTestHedger(t, 100*time.Millisecond, primary, secondary)
+
+	fetched, err := hedger.fetch(context.Background(), primary.client, 100)
+	require.NoError(t, err)
+	require.Same(t, primary.client, fetched.blockClient)
+	require.Zero(t, secondary.calls.Load())
+}
+
+func TestHedger_FailingPrimaryWaitsForHedge(t *testing.T) {
+	primary := newFakeEndpoint(t, "http://primary:26657", 100*time.Millisecond)
+	primary.err = errors.New("primary failed")
+	hedge := newFakeEndpoint(t, "http://hedge:26657", 100*time.Millisecond)
+	hedger := newTestHedger(t, 50*time.Millisecond, primary, hedge)
+
+	fetched, err := hedger.fetch(context.Background(), primary.client, 100)
+	require.NoError(t, err)
+	require.Same(t, hedge.client, fetched.blockClient)
+}
+
+func TestHedger_NoSamplesNoHedge(t *testing.T) {
+	primary := newFakeEndpoint(t, "http://primary:26657", 50*time.Millisecond)
+	primary.err = errors.New("primary failed")
+	secondary := newFakeEndpoint(t, "http://secondary:26657", 0)
+	hedger := newTestHedger(t, 0, primary, secondary)
+	hedger.latencies = nil
+
+	_, err := hedger.fetch(context.Background(), primary.client, 100)
+	require.ErrorContains(t, err, "primary failed")
+	require.Zero(t, secondary.calls.Load())
+}
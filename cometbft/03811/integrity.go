@@ -0,0 +1,127 @@
+package v03811
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	cometType "github.com/cometbft/cometbft/types"
+	pbcosmos "github.com/streamingfast/firehose-cosmos/cosmos/pb/sf/cosmos/type/v2"
+	"go.uber.org/zap"
+)
+
+type IntegrityPolicy string
+
+const (
+	// IntegrityPolicyFlag logs and counts blocks failing the checks, and emits them with the
+	// failed checks listed in their `integrity_check_failures` field
+	IntegrityPolicyFlag IntegrityPolicy = "flag"
+	// IntegrityPolicyFail refuses to emit blocks failing the checks
+	IntegrityPolicyFail IntegrityPolicy = "fail"
+)
+
+// IntegrityChecker proves that a converted block matches the chain: the header `DataHash` must
+// be the merkle root of the txs, and the deterministic hash of the tx results must be the
+// `LastResultsHash` of the next header. The next header is taken from the batch when it holds
+// it, otherwise fetched once the head reached it, so a block is emitted one block later.
+type IntegrityChecker struct {
+	headTracker *HeadTracker
+	batch       *batchFetcher
+	policy      IntegrityPolicy
+	logger      *zap.Logger
+}
+
+func NewIntegrityChecker(headTracker *HeadTracker, policy IntegrityPolicy, logger *zap.Logger) (*IntegrityChecker, error) {
+	if policy != IntegrityPolicyFlag && policy != IntegrityPolicyFail {
+		return nil, fmt.Errorf("invalid integrity policy %q, expected %q or %q", policy, IntegrityPolicyFlag, IntegrityPolicyFail)
+	}
+
+	return &IntegrityChecker{
+		headTracker: headTracker,
+		policy:      policy,
+		logger:      logger,
+	}, nil
+}
+
+func (c *IntegrityChecker) check(ctx context.Context, wrappedClient *CometHttpClientWrap, cosmosBlock *pbcosmos.Block) error {
+	if err := c.checkDataHash(cosmosBlock); err != nil {
+		if err := c.failed(cosmosBlock, wrappedClient, err); err != nil {
+			return err
+		}
+	}
+
+	nextHeader, err := c.fetchNextHeader(ctx, wrappedClient, uint64(cosmosBlock.Height)+1)
+	if err != nil {
+		return fmt.Errorf("fetching header %d to check results hash: %w", cosmosBlock.Height+1, err)
+	}
+	if err := checkResultsHash(cosmosBlock, nextHeader.LastResultsHash); err != nil {
+		return c.failed(cosmosBlock, wrappedClient, err)
+	}
+
+	return nil
+}
+
+func (c *IntegrityChecker) failed(cosmosBlock *pbcosmos.Block, wrappedClient *CometHttpClientWrap, err error) error {
+	integrityCheckFailures.Inc()
+	c.logger.Warn("block failed integrity check", zap.Int64("block_num", cosmosBlock.Height), zap.String("rpc_endpoint", wrappedClient.endpoint), zap.String("policy", string(c.policy)), zap.Error(err))
+
+	if c.policy == IntegrityPolicyFail {
+		return fmt.Errorf("block %d failed integrity check: %w", cosmosBlock.Height, err)
+	}
+	cosmosBlock.IntegrityCheckFailures = append(cosmosBlock.IntegrityCheckFailures, err.Error())
+	return nil
+}
+
+func (c *IntegrityChecker) checkDataHash(cosmosBlock *pbcosmos.Block) error {
+	txs := make(cometType.Txs, len(cosmosBlock.Txs))
+	for i, tx := range cosmosBlock.Txs {
+		txs[i] = tx
+	}
+
+	if computed := txs.Hash(); !bytes.Equal(computed, cosmosBlock.Header.DataHash) {
+		return fmt.Errorf("merkle root of the %d txs %s differs from header data hash %s", len(txs), computed, cmtbytes.HexBytes(cosmosBlock.Header.DataHash))
+	}
+	return nil
+}
+
+// checkResultsHash hashes the fields of the converted tx results that cometbft includes in
+// `LastResultsHash`, so that what is stored is checked rather than the rpc response.
+func checkResultsHash(cosmosBlock *pbcosmos.Block, lastResultsHash []byte) error {
+	results := make([]*abci.ExecTxResult, len(cosmosBlock.TxResults))
+	for i, txResult := range cosmosBlock.TxResults {
+		results[i] = &abci.ExecTxResult{
+			Code:      txResult.Code,
+			Data:      txResult.Data,
+			GasWanted: txResult.GasWanted,
+			GasUsed:   txResult.GasUsed,
+		}
+	}
+
+	if computed := cometType.NewResults(results).Hash(); !bytes.Equal(computed, lastResultsHash) {
+		return fmt.Errorf("hash of the %d tx results %s differs from next header last results hash %s", len(results), cmtbytes.HexBytes(computed), cmtbytes.HexBytes(lastResultsHash))
+	}
+	return nil
+}
+
+func (c *IntegrityChecker) fetchNextHeader(ctx context.Context, wrappedClient *CometHttpClientWrap, blockNum uint64) (*cometType.Header, error) {
+	if next := c.batch.peek(blockNum); next != nil {
+		return &next.block.Block.Header, nil
+	}
+
+	if _, err := c.headTracker.WaitFor(ctx, blockNum); err != nil {
+		return nil, err
+	}
+
+	height := int64(blockNum)
+	result, err := wrappedClient.cometHttpClient.Header(ctx, &height)
+	if err != nil {
+		err = wrappedClient.redactError(err)
+		return nil, err
+	}
+	if result.Header == nil || result.Header.Height != height {
+		return nil, fmt.Errorf("endpoint %q did not serve header %d", wrappedClient.endpoint, blockNum)
+	}
+	return result.Header, nil
+}
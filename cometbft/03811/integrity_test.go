@@ -0,0 +1,86 @@
+package v03811
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pbcosmos "github.com/streamingfast/firehose-cosmos/cosmos/pb/sf/cosmos/type/v2"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestIntegrityChecker_Check(t *testing.T) {
+	cases := []struct {
+		name   string
+		tamper func(block *pbcosmos.Block)
+
+		wantFailure string
+	}{
+		{
+			name:   "untampered block",
+			tamper: func(block *pbcosmos.Block) {},
+		},
+		{
+			name:        "tampered tx",
+			tamper:      func(block *pbcosmos.Block) { block.Txs[0] = []byte("tampered") },
+			wantFailure: "merkle root of the 2 txs",
+		},
+		{
+			name:        "tampered tx results",
+			tamper:      func(block *pbcosmos.Block) { block.TxResults[0].GasUsed++ },
+			wantFailure: "hash of the 2 tx results",
+		},
+	}
+
+	for _, c := range cases {
+		for _, peeked := range []bool{true, false} {
+			for _, policy := range []IntegrityPolicy{IntegrityPolicyFlag, IntegrityPolicyFail} {
+				name := c.name + "/fetched next header/" + string(policy)
+				if peeked {
+					name = c.name + "/peeked next header/" + string(policy)
+				}
+
+				t.Run(name, func(t *testing.T) {
+					storage := newTestChainStorage(t, 10, 11)
+					server := newTestRPCServer(t, storage)
+					client := newTestClient(t, server)
+
+					headTracker := NewHeadTracker(nil, time.Second, time.Minute, nil, zap.NewNop())
+					headTracker.set(11)
+					checker, err := NewIntegrityChecker(headTracker, policy, zap.NewNop())
+					require.NoError(t, err)
+					checker.batch = newBatchFetcher(5, zap.NewNop())
+					if peeked {
+						next, nextResults := storage.rpcResponses(t, 11)
+						checker.batch.blocks[11] = &fetchedBlock{block: next, blockClient: client, blockResults: nextResults, blockResultsClient: client}
+					}
+
+					rpcBlock, rpcBlockResults := storage.rpcResponses(t, 10)
+					block, err := convertBlock(rpcBlock, rpcBlockResults, "rpc", ConversionOptions{}, zap.NewNop())
+					require.NoError(t, err)
+					c.tamper(block)
+
+					err = checker.check(context.Background(), client, block)
+					if peeked {
+						require.Empty(t, server.receivedCalls())
+					} else if err == nil {
+						require.Equal(t, []string{"header 11"}, server.receivedCalls())
+					}
+
+					switch {
+					case c.wantFailure == "":
+						require.NoError(t, err)
+						require.Empty(t, block.IntegrityCheckFailures)
+					case policy == IntegrityPolicyFail:
+						require.ErrorContains(t, err, c.wantFailure)
+					default:
+						require.NoError(t, err)
+						require.Len(t, block.IntegrityCheckFailures, 1)
+						require.Contains(t, block.IntegrityCheckFailures[0], c.wantFailure)
+					}
+				})
+			}
+		}
+	}
+}
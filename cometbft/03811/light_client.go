@@ -0,0 +1,290 @@
+package v03811
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/light"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cometType "github.com/cometbft/cometbft/types"
+	"go.uber.org/zap"
+)
+
+const lightClientCheckpointFile = "light_client_checkpoint.json"
+
+// maxVerifiedHeaders bounds the number of headers verified backwards kept in memory, the cache is
+// cleared once full.
+const maxVerifiedHeaders = 10_000
+
+// LightClientCheckpoint is a block trusted out of band, verification of the following blocks
+// starts from it.
+type LightClientCheckpoint struct {
+	Height int64  `json:"height"`
+	Hash   string `json:"hash"`
+}
+
+type lightBlock struct {
+	signedHeader *cometType.SignedHeader
+	validators   *cometType.ValidatorSet
+}
+
+// LightClientVerifier refuses blocks that were not signed by the validator set, the way a
+// CometBFT light client does: a block following the trusted one must be signed by +2/3 of its
+// validator set, which must be the one announced by the trusted block, and blocks further away
+// are verified by skipping (+1/3 of the trusted validators signed it), bisecting when the
+// validator set changed too much. The last verified block becomes the trusted one, and is
+// persisted in the state directory once accepted. The trusting period is evaluated against the
+// time of the verified block rather than the wall clock so that historical blocks can be verified.
+// Signatures are checked against the header hash computed locally, chains hashing headers
+// differently (see checkBlockID) cannot be verified and their blocks are refused.
+type LightClientVerifier struct {
+	stateFile      string
+	trustingPeriod time.Duration
+	maxClockDrift  time.Duration
+	trustLevel     cmtmath.Fraction
+	logger         *zap.Logger
+
+	lock       sync.Mutex
+	checkpoint LightClientCheckpoint
+	trusted    *lightBlock
+	// headers below the trusted block verified by following the `LastBlockID` hashes, so that
+	// blocks fetched out of order below it do not each walk the chain down from the trusted block
+	verifiedHeaders map[int64]*cometType.Header
+}
+
+// NewLightClientVerifier loads the checkpoint persisted in stateDir, falling back to
+// initialCheckpoint (optional) when there is none.
+func NewLightClientVerifier(stateDir string, initialCheckpoint *LightClientCheckpoint, trustingPeriod time.Duration, maxClockDrift time.Duration, logger *zap.Logger) (*LightClientVerifier, error) {
+	v := &LightClientVerifier{
+		stateFile:      filepath.Join(stateDir, lightClientCheckpointFile),
+		trustingPeriod: trustingPeriod,
+		maxClockDrift:  maxClockDrift,
+		trustLevel:     light.DefaultTrustLevel,
+		logger:         logger,
+
+		verifiedHeaders: map[int64]*cometType.Header{},
+	}
+
+	content, err := os.ReadFile(v.stateFile)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(content, &v.checkpoint); err != nil {
+			return nil, fmt.Errorf("decoding light client checkpoint %q: %w", v.stateFile, err)
+		}
+		logger.Info("loaded light client checkpoint", zap.String("state_file", v.stateFile), zap.Int64("height", v.checkpoint.Height), zap.String("hash", v.checkpoint.Hash))
+	case errors.Is(err, os.ErrNotExist):
+		if initialCheckpoint == nil {
+			return nil, fmt.Errorf("no light client checkpoint in %q, a trusted height and hash are required", v.stateFile)
+		}
+		v.checkpoint = *initialCheckpoint
+	default:
+		return nil, fmt.Errorf("reading light client checkpoint: %w", err)
+	}
+
+	if err := os.MkdirAll(stateDir, 0755); err != nil {
+		return nil, fmt.Errorf("creating state dir: %w", err)
+	}
+
+	if v.checkpoint.Height <= 0 {
+		return nil, fmt.Errorf("invalid light client checkpoint height %d", v.checkpoint.Height)
+	}
+	if _, err := hex.DecodeString(v.checkpoint.Hash); err != nil || v.checkpoint.Hash == "" {
+		return nil, fmt.Errorf("invalid light client checkpoint hash %q, expected hex", v.checkpoint.Hash)
+	}
+
+	return v, nil
+}
+
+func (v *LightClientVerifier) verify(ctx context.Context, wrappedClient *CometHttpClientWrap, rpcBlock *ctypes.ResultBlock) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.trusted == nil {
+		trusted, err := v.loadTrusted(ctx, wrappedClient)
+		if err != nil {
+			return fmt.Errorf("loading light client trusted block %d: %w", v.checkpoint.Height, err)
+		}
+		v.trusted = trusted
+	}
+
+	height := rpcBlock.Block.Height
+	if !bytes.Equal(rpcBlock.Block.Hash(), rpcBlock.BlockID.Hash) {
+		return fmt.Errorf("block %d hash computed from header %s differs from its block id %s, light client verification requires the header hashing of cometbft", height, rpcBlock.Block.Hash(), rpcBlock.BlockID.Hash)
+	}
+	trustedHeight := v.trusted.signedHeader.Height
+	switch {
+	case height == trustedHeight:
+		if !bytes.Equal(rpcBlock.Block.Hash(), v.trusted.signedHeader.Hash()) {
+			return fmt.Errorf("block %d hash %s differs from trusted hash %s", height, rpcBlock.Block.Hash(), v.trusted.signedHeader.Hash())
+		}
+		return nil
+
+	case height < trustedHeight:
+		return v.verifyBackwards(ctx, wrappedClient, rpcBlock)
+	}
+
+	target, err := fetchLightBlock(ctx, wrappedClient, height)
+	if err != nil {
+		return fmt.Errorf("fetching light block %d: %w", height, err)
+	}
+	if !bytes.Equal(rpcBlock.Block.Hash(), target.signedHeader.Commit.BlockID.Hash) {
+		return fmt.Errorf("block %d hash %s is not the one signed by the commit %s", height, rpcBlock.Block.Hash(), target.signedHeader.Commit.BlockID.Hash)
+	}
+
+	if err := v.verifyForward(ctx, wrappedClient, v.trusted, target); err != nil {
+		lightClientVerificationFailures.Inc()
+		return fmt.Errorf("verifying commit signatures of block %d from trusted block %d: %w", height, trustedHeight, err)
+	}
+
+	v.trusted = target
+	return nil
+}
+
+// accept persists the verified block as the checkpoint once it passed every other check and is
+// handed over to the poller, blocks below the checkpoint are ignored.
+func (v *LightClientVerifier) accept(rpcBlock *ctypes.ResultBlock) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if rpcBlock.Block.Height <= v.checkpoint.Height {
+		return nil
+	}
+	return v.saveCheckpoint(LightClientCheckpoint{Height: rpcBlock.Block.Height, Hash: hex.EncodeToString(rpcBlock.Block.Hash())})
+}
+
+// verifyForward verifies target from trusted, bisecting when the validator set changed too much
+// for target to be verified directly.
+func (v *LightClientVerifier) verifyForward(ctx context.Context, wrappedClient *CometHttpClientWrap, trusted *lightBlock, target *lightBlock) error {
+	err := light.Verify(trusted.signedHeader, trusted.validators, target.signedHeader, target.validators, v.trustingPeriod, target.signedHeader.Time, v.maxClockDrift, v.trustLevel)
+	if err == nil {
+		return nil
+	}
+
+	var cantBeTrusted light.ErrNewValSetCantBeTrusted
+	if !errors.As(err, &cantBeTrusted) || target.signedHeader.Height-trusted.signedHeader.Height < 2 {
+		return err
+	}
+
+	pivotHeight := (trusted.signedHeader.Height + target.signedHeader.Height) / 2
+	v.logger.Debug("bisecting light client verification", zap.Int64("trusted_height", trusted.signedHeader.Height), zap.Int64("pivot_height", pivotHeight), zap.Int64("target_height", target.signedHeader.Height))
+	pivot, err := fetchLightBlock(ctx, wrappedClient, pivotHeight)
+	if err != nil {
+		return fmt.Errorf("fetching light block %d: %w", pivotHeight, err)
+	}
+	if err := v.verifyForward(ctx, wrappedClient, trusted, pivot); err != nil {
+		return err
+	}
+	return v.verifyForward(ctx, wrappedClient, pivot, target)
+}
+
+// verifyBackwards follows the `LastBlockID` hashes from the trusted block down to the block,
+// which happens when the poller restarts below the persisted checkpoint. The walk starts from the
+// closest header already verified above the block.
+func (v *LightClientVerifier) verifyBackwards(ctx context.Context, wrappedClient *CometHttpClientWrap, rpcBlock *ctypes.ResultBlock) error {
+	blockHeight := rpcBlock.Block.Height
+	if verified, found := v.verifiedHeaders[blockHeight]; found {
+		if !bytes.Equal(rpcBlock.Block.Hash(), verified.Hash()) {
+			lightClientVerificationFailures.Inc()
+			return fmt.Errorf("block %d hash %s differs from verified hash %s", blockHeight, rpcBlock.Block.Hash(), verified.Hash())
+		}
+		return nil
+	}
+
+	trusted := v.trusted.signedHeader.Header
+	for height := blockHeight + 1; height < trusted.Height; height++ {
+		if verified, found := v.verifiedHeaders[height]; found {
+			trusted = verified
+			break
+		}
+	}
+
+	if len(v.verifiedHeaders)+int(trusted.Height-blockHeight) > maxVerifiedHeaders {
+		v.verifiedHeaders = map[int64]*cometType.Header{}
+	}
+
+	for height := trusted.Height - 1; height > blockHeight; height-- {
+		result, err := wrappedClient.cometHttpClient.Header(ctx, &height)
+		if err != nil {
+			err = wrappedClient.redactError(err)
+			return fmt.Errorf("fetching header %d: %w", height, err)
+		}
+		if err := light.VerifyBackwards(result.Header, trusted); err != nil {
+			return fmt.Errorf("verifying header %d backwards: %w", height, err)
+		}
+		v.verifiedHeaders[height] = result.Header
+		trusted = result.Header
+	}
+
+	if err := light.VerifyBackwards(&rpcBlock.Block.Header, trusted); err != nil {
+		lightClientVerificationFailures.Inc()
+		return fmt.Errorf("verifying block %d backwards: %w", blockHeight, err)
+	}
+	v.verifiedHeaders[blockHeight] = &rpcBlock.Block.Header
+	return nil
+}
+
+func (v *LightClientVerifier) loadTrusted(ctx context.Context, wrappedClient *CometHttpClientWrap) (*lightBlock, error) {
+	trusted, err := fetchLightBlock(ctx, wrappedClient, v.checkpoint.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	if hash := hex.EncodeToString(trusted.signedHeader.Hash()); hash != v.checkpoint.Hash {
+		return nil, fmt.Errorf("endpoint %q serves hash %s, expected trusted hash %s", wrappedClient.endpoint, hash, v.checkpoint.Hash)
+	}
+	if !bytes.Equal(trusted.validators.Hash(), trusted.signedHeader.ValidatorsHash) {
+		return nil, fmt.Errorf("endpoint %q serves a validator set not matching the trusted header", wrappedClient.endpoint)
+	}
+	return trusted, nil
+}
+
+func (v *LightClientVerifier) saveCheckpoint(checkpoint LightClientCheckpoint) error {
+	content, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("encoding light client checkpoint: %w", err)
+	}
+
+	// written then renamed so that a crash never leaves a truncated checkpoint behind
+	tmpFile := v.stateFile + ".tmp"
+	if err := os.WriteFile(tmpFile, content, 0644); err != nil {
+		return fmt.Errorf("writing light client checkpoint: %w", err)
+	}
+	if err := os.Rename(tmpFile, v.stateFile); err != nil {
+		return fmt.Errorf("renaming light client checkpoint: %w", err)
+	}
+
+	v.checkpoint = checkpoint
+	return nil
+}
+
+// fetchLightBlock fetches the signed header and the validator set of a height.
+func fetchLightBlock(ctx context.Context, wrappedClient *CometHttpClientWrap, height int64) (*lightBlock, error) {
+	commit, err := wrappedClient.cometHttpClient.Commit(ctx, &height)
+	if err != nil {
+		err = wrappedClient.redactError(err)
+		return nil, fmt.Errorf("fetching commit: %w", err)
+	}
+	if commit.Header == nil || commit.Commit == nil || commit.Height != height {
+		return nil, fmt.Errorf("endpoint %q did not serve the commit of block %d", wrappedClient.endpoint, height)
+	}
+
+	validatorSet, err := fetchValidatorSet(ctx, wrappedClient, height)
+	if err != nil {
+		return nil, err
+	}
+
+	signedHeader := commit.SignedHeader
+	return &lightBlock{
+		signedHeader: &signedHeader,
+		validators:   validatorSet,
+	}, nil
+}
@@ -0,0 +1,78 @@
+package v03811
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cometType "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestLightClientVerifier_VerifyBackwards(t *testing.T) {
+	storage := newTestChainStorage(t, 1, 30)
+	server := newTestRPCServer(t, storage)
+	client := newTestClient(t, server)
+
+	trusted := storage.blocks[30]
+	verifier, err := NewLightClientVerifier(t.TempDir(), &LightClientCheckpoint{Height: 30, Hash: hex.EncodeToString(trusted.Hash())}, time.Hour, time.Minute, zap.NewNop())
+	require.NoError(t, err)
+	verifier.trusted = &lightBlock{signedHeader: &cometType.SignedHeader{Header: &trusted.Header}}
+
+	cases := []struct {
+		height      int64
+		wantFetched int64
+	}{
+		{height: 20, wantFetched: 9},
+		{height: 25, wantFetched: 0},
+		{height: 21, wantFetched: 0},
+		{height: 10, wantFetched: 9},
+		{height: 15, wantFetched: 0},
+	}
+
+	for _, c := range cases {
+		before := server.singles.Load()
+		block, _ := storage.rpcResponses(t, c.height)
+		require.NoError(t, verifier.verify(context.Background(), client, block), "block %d", c.height)
+		require.Equal(t, c.wantFetched, server.singles.Load()-before, "headers fetched for block %d", c.height)
+	}
+
+	forked, _ := storage.rpcResponses(t, 15)
+	forked.Block.AppHash = []byte("forked")
+	forked.BlockID.Hash = forked.Block.Hash()
+	require.ErrorContains(t, verifier.verify(context.Background(), client, forked), "differs from verified hash")
+
+	patchedHashing, _ := storage.rpcResponses(t, 15)
+	patchedHashing.BlockID.Hash = []byte("hashed by a patched cometbft")
+	require.ErrorContains(t, verifier.verify(context.Background(), client, patchedHashing), "requires the header hashing of cometbft")
+
+	_, err = os.Stat(verifier.stateFile)
+	require.ErrorIs(t, err, os.ErrNotExist, "verifying must not persist the checkpoint")
+}
+
+func TestLightClientVerifier_Accept(t *testing.T) {
+	storage := newTestChainStorage(t, 9, 11)
+	stateDir := t.TempDir()
+
+	verifier, err := NewLightClientVerifier(stateDir, &LightClientCheckpoint{Height: 10, Hash: hex.EncodeToString(storage.blocks[10].Hash())}, time.Hour, time.Minute, zap.NewNop())
+	require.NoError(t, err)
+
+	below, _ := storage.rpcResponses(t, 9)
+	require.NoError(t, verifier.accept(below))
+	_, err = os.Stat(filepath.Join(stateDir, lightClientCheckpointFile))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	above, _ := storage.rpcResponses(t, 11)
+	require.NoError(t, verifier.accept(above))
+
+	content, err := os.ReadFile(filepath.Join(stateDir, lightClientCheckpointFile))
+	require.NoError(t, err)
+	var checkpoint LightClientCheckpoint
+	require.NoError(t, json.Unmarshal(content, &checkpoint))
+	require.Equal(t, LightClientCheckpoint{Height: 11, Hash: hex.EncodeToString(storage.blocks[11].Hash())}, checkpoint)
+}
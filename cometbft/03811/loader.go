@@ -1,6 +1,9 @@
 package v03811
 
 import (
+	"fmt"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	"github.com/cometbft/cometbft/state"
 	txIndex "github.com/cometbft/cometbft/state/txindex/kv"
 	"github.com/cometbft/cometbft/store"
@@ -8,18 +11,23 @@ import (
 	"go.uber.org/zap"
 )
 
+// chainStorageSource identifies blocks read from a node data directory in logs and errors.
+const chainStorageSource = "chain storage"
+
 type BlockLoader struct {
 	blockStore   *store.BlockStore
 	stateStore   state.Store
 	txIndexStore *txIndex.TxIndex
+	conversion   ConversionOptions
 	logger       *zap.Logger
 }
 
-func NewLoader(blockStore *store.BlockStore, stateStore state.Store, txIndexStore *txIndex.TxIndex, logger *zap.Logger) *BlockLoader {
+func NewLoader(blockStore *store.BlockStore, stateStore state.Store, txIndexStore *txIndex.TxIndex, conversion ConversionOptions, logger *zap.Logger) *BlockLoader {
 	return &BlockLoader{
 		blockStore:   blockStore,
 		stateStore:   stateStore,
 		txIndexStore: txIndexStore,
+		conversion:   conversion,
 		logger:       logger,
 	}
 }
@@ -32,83 +40,37 @@ func (l *BlockLoader) BlockRange() (int64, int64) {
 }
 
 func (l *BlockLoader) loadBlock(height int64) (*pbcosmos.Block, error) {
-	panic("implement me")
-	//block := l.blockStore.LoadBlock(height)
-	//if block == nil {
-	//	return nil, fmt.Errorf("block %d not found", height)
-	//}
-	//blockMeta := l.blockStore.LoadBlockMeta(height)
-	//if blockMeta == nil {
-	//	return nil, fmt.Errorf("block meta %d not found", height)
-	//}
-	//abciResponses, err := l.stateStore.LoadABCIResponses(height)
-	//if err != nil {
-	//	return nil, fmt.Errorf("loading finalize block response: %w", err)
-	//}
-	//
-	//header := &pbcosmos.Header{}
-	//err = protoFlip(blockMeta.Header.ToProto(), header)
-	//if err != nil {
-	//	return nil, fmt.Errorf("converting block meta header: %w", err)
-	//}
-	//
-	//consensusParamUpdates := &pbcosmos.ConsensusParams{}
-	//err = protoFlip(abciResponses.EndBlock.ConsensusParamUpdates, consensusParamUpdates)
-	//if err != nil {
-	//	return nil, fmt.Errorf("converting block meta header: %w", err)
-	//}
-	//
-	//abciEvents := abciResponses.BeginBlock.Events
-	//abciEvents = append(abciEvents, abciResponses.EndBlock.Events...)
-	//events := make([]*pbcosmos.Event, len(abciEvents))
-	//for i, _ := range events {
-	//	events[i] = &pbcosmos.Event{}
-	//}
-	//err = arrayProtoFlip(arrayToPointerArray(abciEvents), events)
-	//if err != nil {
-	//	return nil, fmt.Errorf("converting events: %w", err)
-	//}
-	//
-	////trxResults := make([]*pbcosmos.TxResults, len(abciResponses.DeliverTxs))
-	////for i, _ := range trxResults {
-	////	trxResults[i] = &pbcosmos.TxResults{}
-	////}
-	////err = arrayProtoFlip(abciResponses.DeliverTxs, trxResults)
-	////if err != nil {
-	////	return nil, fmt.Errorf("converting tx results: %w", err)
-	////}
-	//
-	//trxResults, err := convertDeliverTxs(abciResponses.DeliverTxs)
-	//if err != nil {
-	//	return nil, fmt.Errorf("converting tx results: %w", err)
-	//}
-	//
-	//validators := make([]*pbcosmos.ValidatorUpdate, len(abciResponses.EndBlock.ValidatorUpdates))
-	//for i, _ := range validators {
-	//	validators[i] = &pbcosmos.ValidatorUpdate{}
-	//}
-	//err = arrayProtoFlip(arrayToPointerArray(abciResponses.EndBlock.ValidatorUpdates), validators)
-	//if err != nil {
-	//	return nil, fmt.Errorf("converting validators: %w", err)
-	//}
-	//
-	//misbehaviors, err := MisbehaviorsFromEvidences(block.Evidence.Evidence)
-	//if err != nil {
-	//	return nil, fmt.Errorf("converting misbehaviors from evidences: %w", err)
-	//}
-	//
-	//pbBlock := &pbcosmos.Block{
-	//	Hash:                  block.Hash(),
-	//	Height:                block.Height,
-	//	Time:                  timestamppb.New(block.Time),
-	//	Header:                header,
-	//	Misbehavior:           misbehaviors,
-	//	Events:                events,
-	//	Txs:                   block.Txs.ToSliceOfBytes(),
-	//	TxResults:             trxResults,
-	//	ValidatorUpdates:      validators,
-	//	ConsensusParamUpdates: consensusParamUpdates,
-	//}
-	//
-	//return pbBlock, nil
+	block := l.blockStore.LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("block %d not found", height)
+	}
+
+	// the stored BlockID is the hash computed by the node, which may hash headers differently
+	blockMeta := l.blockStore.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return nil, fmt.Errorf("block %d meta not found", height)
+	}
+
+	finalizeBlockResponse, err := l.stateStore.LoadFinalizeBlockResponse(height)
+	if err != nil {
+		return nil, fmt.Errorf("loading finalize block response: %w", err)
+	}
+
+	// mirrors what the cometbft rpc `block_results` endpoint builds out of the state store, so
+	// that blocks loaded from a data directory are identical to the ones fetched over rpc
+	blockResults := &ctypes.ResultBlockResults{
+		Height:                height,
+		TxsResults:            finalizeBlockResponse.TxResults,
+		FinalizeBlockEvents:   finalizeBlockResponse.Events,
+		ValidatorUpdates:      finalizeBlockResponse.ValidatorUpdates,
+		ConsensusParamUpdates: finalizeBlockResponse.ConsensusParamUpdates,
+		AppHash:               finalizeBlockResponse.AppHash,
+	}
+
+	pbBlock, err := convertBlock(&ctypes.ResultBlock{BlockID: blockMeta.BlockID, Block: block}, blockResults, chainStorageSource, l.conversion, l.logger)
+	if err != nil {
+		return nil, fmt.Errorf("converting block %d: %w", height, err)
+	}
+
+	return pbBlock, nil
 }
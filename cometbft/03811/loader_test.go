@@ -0,0 +1,225 @@
+package v03811
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmtversion "github.com/cometbft/cometbft/proto/tendermint/version"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/store"
+	cometType "github.com/cometbft/cometbft/types"
+	"github.com/cometbft/cometbft/version"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// testChainStorage is a cometbft node data directory held in memory databases.
+type testChainStorage struct {
+	blockDB    dbm.DB
+	stateDB    dbm.DB
+	blockStore *store.BlockStore
+	stateStore state.Store
+
+	blocks    map[int64]*cometType.Block
+	blockIDs  map[int64]cometType.BlockID
+	responses map[int64]*abci.ResponseFinalizeBlock
+}
+
+func newTestChainStorage(t *testing.T, first int64, last int64) *testChainStorage {
+	t.Helper()
+
+	s := &testChainStorage{
+		blockDB:   dbm.NewMemDB(),
+		stateDB:   dbm.NewMemDB(),
+		blocks:    map[int64]*cometType.Block{},
+		blockIDs:  map[int64]cometType.BlockID{},
+		responses: map[int64]*abci.ResponseFinalizeBlock{},
+	}
+	s.blockStore = store.NewBlockStore(s.blockDB)
+	s.stateStore = state.NewStore(s.stateDB, state.StoreOptions{DiscardABCIResponses: false})
+
+	proposer := ed25519.GenPrivKeyFromSecret([]byte("proposer")).PubKey()
+	validatorsHash := tmhash.Sum([]byte("validators"))
+	consensusHash := tmhash.Sum([]byte("consensus"))
+
+	lastBlockID := cometType.BlockID{}
+	lastCommit := &cometType.Commit{}
+	for height := first; height <= last; height++ {
+		txs := cometType.Txs{[]byte(fmt.Sprintf("tx-%d-0", height)), []byte(fmt.Sprintf("tx-%d-1", height))}
+		block := cometType.MakeBlock(height, txs, lastCommit, nil)
+		block.Header.Populate(
+			cmtversion.Consensus{Block: version.BlockProtocol, App: 1},
+			"test-chain",
+			time.Unix(1_700_000_000+height, 123456789).UTC(),
+			lastBlockID,
+			validatorsHash,
+			validatorsHash,
+			consensusHash,
+			tmhash.Sum([]byte(fmt.Sprintf("app-%d", height-1))),
+			cometType.NewResults(testFinalizeBlockResponse(height-1, proposer.Bytes()).TxResults).Hash(),
+			proposer.Address(),
+		)
+
+		partSet, err := block.MakePartSet(cometType.BlockPartSizeBytes)
+		require.NoError(t, err)
+		blockID := cometType.BlockID{Hash: block.Hash(), PartSetHeader: partSet.Header()}
+		seenCommit := &cometType.Commit{
+			Height:     height,
+			BlockID:    blockID,
+			Signatures: []cometType.CommitSig{cometType.NewCommitSigAbsent()},
+		}
+		s.blockStore.SaveBlock(block, partSet, seenCommit)
+
+		response := testFinalizeBlockResponse(height, proposer.Bytes())
+		require.NoError(t, s.stateStore.SaveFinalizeBlockResponse(height, response))
+
+		s.blocks[height] = block
+		s.blockIDs[height] = blockID
+		s.responses[height] = response
+		lastBlockID = blockID
+		lastCommit = seenCommit
+	}
+
+	return s
+}
+
+func testFinalizeBlockResponse(height int64, validatorPubKey []byte) *abci.ResponseFinalizeBlock {
+	txResult := func(i int) *abci.ExecTxResult {
+		return &abci.ExecTxResult{
+			Code:      uint32(i),
+			Data:      []byte{byte(i)},
+			Log:       fmt.Sprintf("log %d", i),
+			Info:      "info",
+			GasWanted: 200_000,
+			GasUsed:   int64(100_000 + i),
+			Codespace: "sdk",
+			Events: []abci.Event{{
+				Type: "transfer",
+				Attributes: []abci.EventAttribute{
+					{Key: "amount", Value: fmt.Sprintf("%duatom", height), Index: true},
+					{Key: "msg_index", Value: fmt.Sprint(i)},
+				},
+			}},
+		}
+	}
+
+	return &abci.ResponseFinalizeBlock{
+		TxResults: []*abci.ExecTxResult{txResult(0), txResult(1)},
+		Events: []abci.Event{
+			{Type: "mint", Attributes: []abci.EventAttribute{{Key: "mode", Value: "BeginBlock"}}},
+			{Type: "complete_unbonding", Attributes: []abci.EventAttribute{{Key: "mode", Value: "EndBlock", Index: true}}},
+		},
+		ValidatorUpdates: []abci.ValidatorUpdate{{
+			PubKey: cmtcrypto.PublicKey{Sum: &cmtcrypto.PublicKey_Ed25519{Ed25519: validatorPubKey}},
+			Power:  10,
+		}},
+		ConsensusParamUpdates: &cmtproto.ConsensusParams{
+			Block: &cmtproto.BlockParams{MaxBytes: 22020096, MaxGas: -1},
+		},
+		AppHash: tmhash.Sum([]byte(fmt.Sprintf("app-%d", height))),
+	}
+}
+
+// rpcResponses returns the `block` and `block_results` rpc responses of a height the way the
+// rpc client decodes them.
+func (s *testChainStorage) rpcResponses(t *testing.T, height int64) (*ctypes.ResultBlock, *ctypes.ResultBlockResults) {
+	t.Helper()
+
+	response := s.responses[height]
+	roundTrip := func(in any, out any) {
+		content, err := cmtjson.Marshal(in)
+		require.NoError(t, err)
+		require.NoError(t, cmtjson.Unmarshal(content, out))
+	}
+
+	rpcBlock := &ctypes.ResultBlock{}
+	roundTrip(&ctypes.ResultBlock{BlockID: s.blockIDs[height], Block: s.blocks[height]}, rpcBlock)
+
+	rpcBlockResults := &ctypes.ResultBlockResults{}
+	roundTrip(&ctypes.ResultBlockResults{
+		Height:                height,
+		TxsResults:            response.TxResults,
+		FinalizeBlockEvents:   response.Events,
+		ValidatorUpdates:      response.ValidatorUpdates,
+		ConsensusParamUpdates: response.ConsensusParamUpdates,
+		AppHash:               response.AppHash,
+	}, rpcBlockResults)
+
+	return rpcBlock, rpcBlockResults
+}
+
+func TestBlockLoader_LoadBlockMatchesRPC(t *testing.T) {
+	storage := newTestChainStorage(t, 10, 12)
+
+	for _, conversion := range []ConversionOptions{{}, {LosslessEventAttributes: true, DecodeTxs: true, VoteExtensions: VoteExtensionsSourceInjectedTx}} {
+		loader := NewLoader(storage.blockStore, storage.stateStore, nil, conversion, zap.NewNop())
+
+		for height := int64(10); height <= 12; height++ {
+			loaded, err := loader.loadBlock(height)
+			require.NoError(t, err)
+
+			rpcBlock, rpcBlockResults := storage.rpcResponses(t, height)
+			fetched, err := convertBlock(rpcBlock, rpcBlockResults, "rpc", conversion, zap.NewNop())
+			require.NoError(t, err)
+
+			require.True(t, proto.Equal(fetched, loaded), "block %d with %+v differs:\nrpc:    %v\nloaded: %v", height, conversion, fetched, loaded)
+			require.Len(t, loaded.TxResults, 2)
+			require.Len(t, loaded.Events, 2)
+		}
+	}
+}
+
+func TestBlockLoader_LoadBlockNotFound(t *testing.T) {
+	storage := newTestChainStorage(t, 10, 10)
+	loader := NewLoader(storage.blockStore, storage.stateStore, nil, ConversionOptions{}, zap.NewNop())
+
+	_, err := loader.loadBlock(11)
+	require.Error(t, err)
+}
+
+func TestBlockLoader_LoadBlockIDMismatch(t *testing.T) {
+	storage := newTestChainStorage(t, 10, 10)
+
+	// a node hashing headers differently stores another BlockID than the one computed locally
+	blockMeta := storage.blockStore.LoadBlockMeta(10)
+	nodeHash := tmhash.Sum([]byte("hashed by a patched cometbft"))
+	blockMeta.BlockID.Hash = nodeHash
+	content, err := gogoproto.Marshal(blockMeta.ToProto())
+	require.NoError(t, err)
+	require.NoError(t, storage.blockDB.Set([]byte("H:10"), content))
+
+	cases := []struct {
+		policy   BlockIDMismatchPolicy
+		wantHash []byte
+		wantErr  bool
+	}{
+		{policy: BlockIDMismatchPolicyFail, wantErr: true},
+		{policy: BlockIDMismatchPolicyUseReported, wantHash: nodeHash},
+		{policy: "", wantHash: nodeHash},
+	}
+
+	for _, c := range cases {
+		t.Run(string(c.policy), func(t *testing.T) {
+			loader := NewLoader(storage.blockStore, storage.stateStore, nil, ConversionOptions{BlockIDPolicy: c.policy}, zap.NewNop())
+
+			block, err := loader.loadBlock(10)
+			if c.wantErr {
+				require.ErrorContains(t, err, "differs from block id")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.wantHash, block.Hash)
+		})
+	}
+}
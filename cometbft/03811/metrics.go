@@ -0,0 +1,62 @@
+package v03811
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/streamingfast/dmetrics"
+	"go.uber.org/zap"
+)
+
+func RegisterMetrics() {
+	metrics.Register()
+}
+
+// ServeMetrics serves the Prometheus metrics on the address. Unlike `dmetrics.Serve`, which only
+// logs at debug level when it cannot listen, the address is bound before returning so that a
+// port already taken fails the command.
+func ServeMetrics(addr string, logger *zap.Logger) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listening on metrics address %q: %w", addr, err)
+	}
+
+	go func() {
+		if err := http.Serve(listener, promhttp.Handler()); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Warn("metrics server stopped", zap.String("listen_addr", addr), zap.Error(err))
+		}
+	}()
+
+	logger.Info("serving prometheus metrics", zap.String("listen_addr", addr))
+	return nil
+}
+
+var metrics = dmetrics.NewSet()
+
+var headBlockNumber = metrics.NewGauge("firecosmos_rpc_fetcher_head_block_number", "Latest block number known by the rpc fetcher head tracker")
+
+var hedgedRequests = metrics.NewCounter("firecosmos_rpc_fetcher_hedged_requests", "Number of block fetches sent to a second endpoint because the first one was too slow")
+var hedgedRequestsWasted = metrics.NewCounter("firecosmos_rpc_fetcher_hedged_requests_wasted", "Number of hedged block fetches whose in-flight sibling request was cancelled")
+var hedgedRequestsWon = metrics.NewCounter("firecosmos_rpc_fetcher_hedged_requests_won", "Number of hedged block fetches where the second endpoint answered first")
+
+var circuitBreakerOpened = metrics.NewCounter("firecosmos_rpc_endpoint_circuit_breaker_opened", "Number of times an endpoint circuit breaker opened")
+var rateLimitedResponses = metrics.NewCounter("firecosmos_rpc_endpoint_rate_limited_responses", "Number of HTTP 429 responses received from endpoints")
+
+var verifiedBlocks = metrics.NewCounter("firecosmos_rpc_fetcher_verified_blocks", "Number of blocks on which all the verifying endpoints agreed")
+var verificationMismatches = metrics.NewCounter("firecosmos_rpc_fetcher_verification_mismatches", "Number of blocks on which the verifying endpoints disagreed")
+var quarantinedEndpoints = metrics.NewCounter("firecosmos_rpc_fetcher_quarantined_endpoints", "Number of times an endpoint was quarantined for serving a block disagreeing with the majority")
+
+var blockIDMismatches = metrics.NewCounter("firecosmos_rpc_fetcher_block_id_mismatches", "Number of blocks whose hash computed from the header differs from the rpc BlockID")
+
+var integrityCheckFailures = metrics.NewCounter("firecosmos_rpc_fetcher_integrity_check_failures", "Number of blocks whose txs or tx results do not match the merkle roots of the chain headers")
+
+var lightClientVerificationFailures = metrics.NewCounter("firecosmos_rpc_fetcher_light_client_verification_failures", "Number of blocks refused because their commit signatures could not be verified")
+
+var validatorSetFetches = metrics.NewCounter("firecosmos_rpc_fetcher_validator_set_fetches", "Number of validator sets fetched to be embedded in blocks")
+
+var invalidUTF8Strings = metrics.NewCounterVec("firecosmos_block_conversion_invalid_utf8_strings", []string{"field"}, "Number of abci result strings that were not valid UTF-8, by field")
+
+var undecodableTxs = metrics.NewCounter("firecosmos_block_conversion_undecodable_txs", "Number of txs whose Cosmos SDK envelope could not be decoded, kept as raw bytes")
@@ -0,0 +1,96 @@
+package v03811
+
+import (
+	"fmt"
+)
+
+type EndpointTier string
+
+const (
+	// EndpointTierRecent is a pruned endpoint, preferred for blocks close to the head
+	EndpointTierRecent EndpointTier = "recent"
+	// EndpointTierArchive is an endpoint holding the full history, preferred for historical blocks
+	EndpointTierArchive EndpointTier = "archive"
+)
+
+// EndpointRouter decides which endpoints may serve a given height. Blocks older than the recent
+// blocks window are sent to archive endpoints and blocks close to the head to recent ones. An
+// endpoint of the other tier is only used when no healthy endpoint of the preferred tier holds
+// the block, so that archive nodes pick up heights that recent nodes already pruned.
+type EndpointRouter struct {
+	clients            []*CometHttpClientWrap
+	headTracker        *HeadTracker
+	recentBlocksWindow uint64
+}
+
+func NewEndpointRouter(clients []*CometHttpClientWrap, headTracker *HeadTracker, recentBlocksWindow uint64) *EndpointRouter {
+	return &EndpointRouter{
+		clients:            clients,
+		headTracker:        headTracker,
+		recentBlocksWindow: recentBlocksWindow,
+	}
+}
+
+// Route returns an error when the client should not be used to fetch the block.
+func (r *EndpointRouter) Route(client *CometHttpClientWrap, blockNum uint64) error {
+	if client.Quarantined() {
+		return fmt.Errorf("endpoint %q is quarantined", client.endpoint)
+	}
+
+	if !client.HoldsBlock(blockNum) {
+		earliest, latest := client.BlockRange()
+		return fmt.Errorf("endpoint %q does not hold block %d, its range is [%d, %d]", client.endpoint, blockNum, earliest, latest)
+	}
+
+	preferredTier := r.preferredTier(blockNum)
+	if client.tier == preferredTier {
+		return nil
+	}
+
+	if r.hasHealthyHolder(preferredTier, blockNum) {
+		return fmt.Errorf("block %d is routed to %s endpoints, skipping %s endpoint %q", blockNum, preferredTier, client.tier, client.endpoint)
+	}
+
+	return nil
+}
+
+func (r *EndpointRouter) preferredTier(blockNum uint64) EndpointTier {
+	head := r.headTracker.LatestBlockNum()
+	if head > r.recentBlocksWindow && blockNum < head-r.recentBlocksWindow {
+		return EndpointTierArchive
+	}
+	return EndpointTierRecent
+}
+
+func (r *EndpointRouter) hasHealthyHolder(tier EndpointTier, blockNum uint64) bool {
+	for _, client := range r.clients {
+		if client.tier == tier && client.Healthy() && client.HoldsBlock(blockNum) {
+			return true
+		}
+	}
+	return false
+}
+
+// Alternative returns a healthy client other than the excluded one that may serve the block,
+// nil when there is none.
+func (r *EndpointRouter) Alternative(exclude *CometHttpClientWrap, blockNum uint64) *CometHttpClientWrap {
+	for _, client := range r.clients {
+		if client == exclude || !client.Healthy() {
+			continue
+		}
+		if r.Route(client, blockNum) == nil {
+			return client
+		}
+	}
+	return nil
+}
+
+// AnyClientHolds tells if at least one client is known to hold the block.
+func (r *EndpointRouter) AnyClientHolds(blockNum uint64) bool {
+	for _, client := range r.clients {
+		if client.HoldsBlock(blockNum) {
+			return true
+		}
+	}
+	return false
+}
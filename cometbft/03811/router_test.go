@@ -0,0 +1,119 @@
+package v03811
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestEndpointRouter_Route(t *testing.T) {
+	newClient := func(tier EndpointTier, earliest int64, latest int64) *CometHttpClientWrap {
+		client, err := NewCometHttpClientWrap("http://node:26657", tier, EndpointLimits{}, nil, zap.NewNop())
+		require.NoError(t, err)
+		client.earliestBlockHeight.Store(earliest)
+		client.latestBlockHeight.Store(latest)
+		return client
+	}
+
+	pruned := newClient(EndpointTierRecent, 900, 1000)
+	lagging := newClient(EndpointTierRecent, 1, 950)
+	unknown := newClient(EndpointTierRecent, 0, 0)
+
+	headTracker := NewHeadTracker(nil, time.Second, time.Minute, nil, zap.NewNop())
+	headTracker.set(1000)
+	router := NewEndpointRouter([]*CometHttpClientWrap{pruned, lagging, unknown}, headTracker, 0)
+
+	cases := []struct {
+		name     string
+		client   *CometHttpClientWrap
+		blockNum uint64
+		wantErr  bool
+	}{
+		{"pruned endpoint holding the block", pruned, 990, false},
+		{"pruned endpoint below its earliest height", pruned, 500, true},
+		{"lagging endpoint below its latest height", lagging, 500, false},
+		{"lagging endpoint above its latest height", lagging, 990, true},
+		{"endpoint whose status is unknown", unknown, 990, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := router.Route(c.client, c.blockNum)
+			if c.wantErr {
+				require.ErrorContains(t, err, "does not hold block")
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	require.True(t, router.AnyClientHolds(500))
+	require.True(t, router.AnyClientHolds(990))
+	require.False(t, router.AnyClientHolds(1001))
+
+	lagging.observeLatest(990)
+	require.NoError(t, router.Route(lagging, 990))
+}
+
+func TestEndpointRouter_RouteTiers(t *testing.T) {
+	newClient := func(tier EndpointTier, earliest int64) *CometHttpClientWrap {
+		client, err := NewCometHttpClientWrap("http://node:26657", tier, EndpointLimits{}, nil, zap.NewNop())
+		require.NoError(t, err)
+		client.earliestBlockHeight.Store(earliest)
+		client.latestBlockHeight.Store(1000)
+		return client
+	}
+
+	cases := []struct {
+		name               string
+		recentBlocksWindow uint64
+		blockNum           uint64
+		quarantined        EndpointTier
+		wantArchiveErr     string
+		wantRecentErr      string
+	}{
+		{name: "head block goes to recent endpoints", recentBlocksWindow: 50, blockNum: 990, wantArchiveErr: "routed to recent endpoints"},
+		{name: "edge of the window goes to recent endpoints", recentBlocksWindow: 50, blockNum: 950, wantArchiveErr: "routed to recent endpoints"},
+		{name: "historical block goes to archive endpoints", recentBlocksWindow: 50, blockNum: 920, wantRecentErr: "routed to archive endpoints"},
+		{name: "block pruned by recent endpoints", recentBlocksWindow: 50, blockNum: 800, wantRecentErr: "does not hold block 800"},
+		{name: "recent endpoints unhealthy", recentBlocksWindow: 50, blockNum: 990, quarantined: EndpointTierRecent, wantRecentErr: "quarantined"},
+		{name: "archive endpoints unhealthy", recentBlocksWindow: 50, blockNum: 920, quarantined: EndpointTierArchive, wantArchiveErr: "quarantined"},
+		{name: "no window, below the head", recentBlocksWindow: 0, blockNum: 999, wantRecentErr: "routed to archive endpoints"},
+		{name: "no window, at the head", recentBlocksWindow: 0, blockNum: 1000, wantArchiveErr: "routed to recent endpoints"},
+		{name: "window above the head", recentBlocksWindow: 5000, blockNum: 920, wantArchiveErr: "routed to recent endpoints"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			archive := newClient(EndpointTierArchive, 1)
+			recent := newClient(EndpointTierRecent, 900)
+			for _, client := range []*CometHttpClientWrap{archive, recent} {
+				if client.tier == c.quarantined {
+					client.Quarantine(time.Minute)
+				}
+			}
+
+			headTracker := NewHeadTracker(nil, time.Second, time.Minute, nil, zap.NewNop())
+			headTracker.set(1000)
+			router := NewEndpointRouter([]*CometHttpClientWrap{archive, recent}, headTracker, c.recentBlocksWindow)
+
+			for _, route := range []struct {
+				client  *CometHttpClientWrap
+				wantErr string
+			}{
+				{archive, c.wantArchiveErr},
+				{recent, c.wantRecentErr},
+			} {
+				err := router.Route(route.client, c.blockNum)
+				if route.wantErr == "" {
+					require.NoError(t, err, "%s endpoint", route.client.tier)
+					require.Same(t, route.client, router.Alternative(nil, c.blockNum))
+					continue
+				}
+				require.ErrorContains(t, err, route.wantErr, "%s endpoint", route.client.tier)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package v03811
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+// errBatchRejected is returned when the endpoint answers a batch with a JSON-RPC error telling
+// that batches are not supported. Any other failure, such as an error page of a proxy or every
+// call of the batch failing, may be transient and is not a rejection.
+var errBatchRejected = errors.New("endpoint refuses batch requests")
+
+// rpcBatchCall is one call of a JSON-RPC batch, its result is decoded into result.
+type rpcBatchCall struct {
+	method string
+	params map[string]any
+	result any
+}
+
+// rpcBatchClient sends JSON-RPC batches to an endpoint. The batches of the cometbft client cannot
+// tell an endpoint refusing batches from a transient failure: a single object reply is accepted
+// as a successful batch and any erroring call fails the whole batch.
+type rpcBatchClient struct {
+	address    string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// newRPCBatchClient posts to the same address as the cometbft client built for the endpoint.
+func newRPCBatchClient(endpoint string, httpClient *http.Client) (*rpcBatchClient, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", RedactEndpoint(endpoint), err)
+	}
+
+	hostWithPath := u.Host + u.EscapedPath()
+	if u.Scheme == "unix" {
+		// the socket is dialed by the http client transport, the host only has to be valid
+		hostWithPath = strings.ReplaceAll(hostWithPath, "/", ".")
+	}
+
+	scheme := u.Scheme
+	switch scheme {
+	case "http", "https", "ws", "wss":
+	default:
+		scheme = "http"
+	}
+
+	password, _ := u.User.Password()
+	return &rpcBatchClient{
+		address:    scheme + "://" + hostWithPath,
+		username:   u.User.Username(),
+		password:   password,
+		httpClient: httpClient,
+	}, nil
+}
+
+// send posts the calls as a single batch. The error of each call is returned separately, the
+// returned error is for the batch as a whole and wraps errBatchRejected when the endpoint says it
+// does not support batches.
+func (c *rpcBatchClient) send(ctx context.Context, calls []*rpcBatchCall) ([]error, error) {
+	requests := make([]types.RPCRequest, len(calls))
+	for i, call := range calls {
+		request, err := types.MapToRequest(types.JSONRPCIntID(i), call.method, call.params)
+		if err != nil {
+			return nil, fmt.Errorf("encoding %s call: %w", call.method, err)
+		}
+		requests[i] = request
+	}
+
+	body, err := json.Marshal(requests)
+	if err != nil {
+		return nil, fmt.Errorf("encoding batch: %w", err)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, c.address, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	if c.username != "" || c.password != "" {
+		httpRequest.SetBasicAuth(c.username, c.password)
+	}
+
+	httpResponse, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("post: %w", err)
+	}
+	defer httpResponse.Body.Close()
+
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+
+	// nodes answer JSON-RPC errors with a 200 or a 500 status, a proxy error page is not one
+	var responses []types.RPCResponse
+	trimmed := bytes.TrimSpace(responseBody)
+	single := len(trimmed) > 0 && trimmed[0] == '{'
+	if single {
+		var response types.RPCResponse
+		if err := json.Unmarshal(trimmed, &response); err == nil && response.Error != nil {
+			responses = []types.RPCResponse{response}
+		}
+	} else if len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &responses); err != nil {
+			responses = nil
+		}
+	}
+	if responses == nil {
+		return nil, fmt.Errorf("unexpected reply with http status %d", httpResponse.StatusCode)
+	}
+
+	if len(responses) != len(calls) {
+		if batchUnsupported(responses, single) {
+			return nil, fmt.Errorf("%w: %s", errBatchRejected, responses[0].Error)
+		}
+		return nil, fmt.Errorf("got %d responses for %d calls with http status %d", len(responses), len(calls), httpResponse.StatusCode)
+	}
+
+	errs := make([]error, len(calls))
+	answered := make([]bool, len(calls))
+	for _, response := range responses {
+		id, ok := response.ID.(types.JSONRPCIntID)
+		if !ok || id < 0 || int(id) >= len(calls) || answered[id] {
+			return nil, fmt.Errorf("unexpected response id %v", response.ID)
+		}
+		answered[id] = true
+
+		if response.Error != nil {
+			errs[id] = response.Error
+			continue
+		}
+		if err := cmtjson.Unmarshal(response.Result, calls[id].result); err != nil {
+			errs[id] = fmt.Errorf("decoding %s result: %w", calls[id].method, err)
+		}
+	}
+	return errs, nil
+}
+
+// batchUnsupported tells if the responses are errors saying that batches are not supported.
+// Servers only accepting single requests reject the array with a single invalid request error
+// without an id, proxies refusing batches say so in the message.
+func batchUnsupported(responses []types.RPCResponse, single bool) bool {
+	if len(responses) == 0 {
+		return false
+	}
+
+	for _, response := range responses {
+		if response.Error == nil {
+			return false
+		}
+
+		message := strings.ToLower(response.Error.Message + " " + response.Error.Data)
+		invalidRequest := single && response.Error.Code == -32600 && response.ID == nil
+		refusingBatches := strings.Contains(message, "batch") && containsAny(message, "not supported", "unsupported", "not allowed", "disabled")
+		if !invalidRequest && !refusingBatches {
+			return false
+		}
+	}
+	return true
+}
+
+func containsAny(s string, substrings ...string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(s, substring) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package v03811
+
+import (
+	"fmt"
+	"path/filepath"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/state"
+	txindexkv "github.com/cometbft/cometbft/state/txindex/kv"
+	"github.com/cometbft/cometbft/store"
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli"
+	"github.com/streamingfast/cli/sflags"
+	"github.com/streamingfast/dstore"
+	firecore "github.com/streamingfast/firehose-core"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+)
+
+// memdb is left out on purpose, a memory database opened from the command line is always empty
+var supportedDBBackends = []dbm.BackendType{dbm.GoLevelDBBackend, dbm.PebbleDBBackend}
+
+func NewToolsExtractFromChainStorageCmd(logger *zap.Logger, tracer logging.Tracer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "extract-from-chain-storage",
+		Short: "generate merged blocks files straight from a cometbft node data directory",
+		Long: cli.Dedent(`
+			Reads blocks and finalize block responses from the 'blockstore', 'state' and 'tx_index'
+			databases of a cometbft node and writes them as merged blocks files to the destination store.
+
+			The node must have been run with 'discard_abci_responses = false' for the requested range,
+			otherwise block results are not available. The 'pebbledb' backend requires a binary built
+			with the 'pebbledb' build tag.
+		`),
+		Args: cobra.NoArgs,
+		RunE: extractFromChainStorageRunE(logger, tracer),
+	}
+
+	cmd.Flags().String("home-dir", "", "cometbft node home directory, databases are read from its 'data' sub-directory")
+	cmd.Flags().String("db-backend", string(dbm.GoLevelDBBackend), fmt.Sprintf("database backend used by the node, one of %v", supportedDBBackends))
+	cmd.Flags().String("destination-store", "", "dstore URL where merged blocks files are written")
+	cmd.Flags().Int64("start-block", 0, "first block to extract, must be the start of a 100 blocks bundle")
+	cmd.Flags().Int64("stop-block", 0, "exclusive stop block, must be a multiple of 100")
+	cmd.Flags().Bool("lossless-event-attributes", false, "keep the index flag and raw bytes of event attributes")
+	cmd.Flags().String("utf8-policy", "replace", "what to do with invalid UTF-8 in abci results, one of 'replace', 'keep-raw' or 'fail'")
+	cmd.Flags().String("block-id-mismatch-policy", "use-reported", "what to do when the header hash differs from the stored BlockID, one of 'use-reported' or 'fail'")
+	cmd.Flags().String("vote-extensions-source", "", "if set, where to read the vote extensions of each block from, only 'injected-tx' is supported")
+	cmd.Flags().Bool("decode-txs", false, "decode the Cosmos SDK envelope of each tx")
+
+	return cmd
+}
+
+func extractFromChainStorageRunE(logger *zap.Logger, _ logging.Tracer) firecore.CommandExecutor {
+	return func(cmd *cobra.Command, args []string) (err error) {
+		homeDir := sflags.MustGetString(cmd, "home-dir")
+		if homeDir == "" {
+			return fmt.Errorf("--home-dir is required")
+		}
+
+		dbBackend, err := parseDBBackend(sflags.MustGetString(cmd, "db-backend"))
+		if err != nil {
+			return err
+		}
+
+		utf8Policy, err := ParseUTF8Policy(sflags.MustGetString(cmd, "utf8-policy"))
+		if err != nil {
+			return err
+		}
+		blockIDPolicy, err := ParseBlockIDMismatchPolicy(sflags.MustGetString(cmd, "block-id-mismatch-policy"))
+		if err != nil {
+			return err
+		}
+		var voteExtensionsSource VoteExtensionsSource
+		if source := sflags.MustGetString(cmd, "vote-extensions-source"); source != "" {
+			if voteExtensionsSource, err = ParseVoteExtensionsSource(source); err != nil {
+				return err
+			}
+		}
+		conversion := ConversionOptions{
+			LosslessEventAttributes: sflags.MustGetBool(cmd, "lossless-event-attributes"),
+			UTF8Policy:              utf8Policy,
+			DecodeTxs:               sflags.MustGetBool(cmd, "decode-txs"),
+			BlockIDPolicy:           blockIDPolicy,
+			VoteExtensions:          voteExtensionsSource,
+		}
+
+		destStore, err := dstore.NewDBinStore(sflags.MustGetString(cmd, "destination-store"))
+		if err != nil {
+			return fmt.Errorf("unable to create destination store: %w", err)
+		}
+
+		startBlock := sflags.MustGetInt64(cmd, "start-block")
+		stopBlock := sflags.MustGetInt64(cmd, "stop-block")
+		if stopBlock <= startBlock {
+			return fmt.Errorf("stop block %d must be greater than start block %d", stopBlock, startBlock)
+		}
+		if startBlock%100 != 0 {
+			return fmt.Errorf("start block %d is not a boundary", startBlock)
+		}
+
+		dataDir := filepath.Join(homeDir, "data")
+
+		logger.Info(
+			"extracting blocks from chain storage",
+			zap.String("data_dir", dataDir),
+			zap.String("db_backend", string(dbBackend)),
+			zap.String("destination_store", destStore.BaseURL().String()),
+			zap.Int64("start_block", startBlock),
+			zap.Int64("stop_block", stopBlock),
+		)
+
+		blockDB, err := dbm.NewDB("blockstore", dbBackend, dataDir)
+		if err != nil {
+			return fmt.Errorf("opening blockstore db: %w", err)
+		}
+		defer blockDB.Close()
+		blockStore := store.NewBlockStore(blockDB)
+
+		stateDB, err := dbm.NewDB("state", dbBackend, dataDir)
+		if err != nil {
+			return fmt.Errorf("opening state db: %w", err)
+		}
+		defer stateDB.Close()
+		stateStore := state.NewStore(stateDB, state.StoreOptions{
+			DiscardABCIResponses: false,
+		})
+
+		txIndexDB, err := dbm.NewDB("tx_index", dbBackend, dataDir)
+		if err != nil {
+			return fmt.Errorf("opening tx_index db: %w", err)
+		}
+		defer txIndexDB.Close()
+		txIndexStore := txindexkv.NewTxIndex(txIndexDB)
+
+		loader := NewLoader(blockStore, stateStore, txIndexStore, conversion, logger)
+		merger := NewSimpleMerger(loader, logger)
+
+		err = merger.GenerateMergeBlock(startBlock, stopBlock, destStore)
+		if err != nil {
+			return fmt.Errorf("generating merge blocks files: %w", err)
+		}
+
+		return nil
+	}
+}
+
+func parseDBBackend(in string) (dbm.BackendType, error) {
+	for _, backend := range supportedDBBackends {
+		if string(backend) == in {
+			return backend, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported db backend %q, expected one of %v", in, supportedDBBackends)
+}
@@ -0,0 +1,75 @@
+package v03811
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/dstore"
+	pbcosmos "github.com/streamingfast/firehose-cosmos/cosmos/pb/sf/cosmos/type/v2"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSimpleMerge_GenerateMergeBlockFromMemDB(t *testing.T) {
+	storage := newTestChainStorage(t, 100, 200)
+	loader := NewLoader(storage.blockStore, storage.stateStore, nil, ConversionOptions{}, zap.NewNop())
+
+	destStore, err := dstore.NewDBinStore(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, NewSimpleMerger(loader, zap.NewNop()).GenerateMergeBlock(100, 200, destStore))
+
+	reader, err := destStore.OpenObject(context.Background(), "0000000100")
+	require.NoError(t, err)
+	defer reader.Close()
+
+	blockReader, err := bstream.NewDBinBlockReader(reader)
+	require.NoError(t, err)
+
+	expectedNum := uint64(100)
+	for {
+		block, err := blockReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+
+		height := int64(expectedNum)
+		require.Equal(t, expectedNum, block.Number)
+		require.Equal(t, hex.EncodeToString(storage.blockIDs[height].Hash), block.Id)
+		if height > 100 {
+			require.Equal(t, hex.EncodeToString(storage.blockIDs[height-1].Hash), block.ParentId)
+		}
+
+		cosmosBlock := &pbcosmos.Block{}
+		require.NoError(t, block.Payload.UnmarshalTo(cosmosBlock))
+		require.Equal(t, height, cosmosBlock.Height)
+		require.Len(t, cosmosBlock.TxResults, 2)
+
+		expectedNum++
+	}
+	require.Equal(t, uint64(200), expectedNum, "merged file should hold blocks 100 to 199")
+}
+
+func TestSimpleMerge_GenerateMergeBlockOutOfRange(t *testing.T) {
+	storage := newTestChainStorage(t, 100, 150)
+	loader := NewLoader(storage.blockStore, storage.stateStore, nil, ConversionOptions{}, zap.NewNop())
+
+	destStore, err := dstore.NewDBinStore(t.TempDir())
+	require.NoError(t, err)
+
+	require.Error(t, NewSimpleMerger(loader, zap.NewNop()).GenerateMergeBlock(100, 200, destStore))
+}
+
+func TestParseDBBackend(t *testing.T) {
+	backend, err := parseDBBackend("goleveldb")
+	require.NoError(t, err)
+	require.Equal(t, "goleveldb", string(backend))
+
+	_, err = parseDBBackend("memdb")
+	require.Error(t, err)
+}
@@ -0,0 +1,76 @@
+package v03811
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	cometType "github.com/cometbft/cometbft/types"
+	pbcosmos "github.com/streamingfast/firehose-cosmos/cosmos/pb/sf/cosmos/type/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// decodeTxs decodes the Cosmos SDK envelope of each tx, txs that cannot be decoded are kept as
+// raw bytes along with the decoding error.
+func decodeTxs(txs cometType.Txs) []*pbcosmos.Tx {
+	decodedTxs := make([]*pbcosmos.Tx, len(txs))
+	for i, tx := range txs {
+		decodedTxs[i] = decodeTx(tx)
+	}
+	return decodedTxs
+}
+
+func decodeTx(tx cometType.Tx) *pbcosmos.Tx {
+	out := &pbcosmos.Tx{
+		Hash: tx.Hash(),
+	}
+
+	body, authInfo, signatures, err := decodeTxEnvelope(tx)
+	if err != nil {
+		undecodableTxs.Inc()
+		out.Raw = tx
+		out.DecodeError = err.Error()
+		return out
+	}
+
+	out.Body = body
+	out.AuthInfo = authInfo
+	out.Signatures = signatures
+	return out
+}
+
+// decodeTxEnvelope decodes a `cosmos.tx.v1beta1.TxRaw`. The protobuf encoding being permissive,
+// bytes of another format (such as an EVM tx) may decode as one, so the envelope must also look
+// like what the SDK produces: no unknown field and at least one message with a type url.
+func decodeTxEnvelope(tx []byte) (*pbcosmos.TxBody, *pbcosmos.AuthInfo, [][]byte, error) {
+	raw := &pbcosmos.TxRaw{}
+	if err := proto.Unmarshal(tx, raw); err != nil {
+		return nil, nil, nil, fmt.Errorf("decoding tx raw: %w", err)
+	}
+	if len(raw.ProtoReflect().GetUnknown()) > 0 {
+		return nil, nil, nil, errors.New("decoding tx raw: unknown fields")
+	}
+
+	// fields added by later SDK versions are dropped rather than kept as unknown bytes
+	unmarshalOptions := proto.UnmarshalOptions{DiscardUnknown: true}
+
+	body := &pbcosmos.TxBody{}
+	if err := unmarshalOptions.Unmarshal(raw.BodyBytes, body); err != nil {
+		return nil, nil, nil, fmt.Errorf("decoding tx body: %w", err)
+	}
+	if len(body.Messages) == 0 {
+		return nil, nil, nil, errors.New("tx body has no message")
+	}
+	for i, message := range body.Messages {
+		if !strings.HasPrefix(message.TypeUrl, "/") {
+			return nil, nil, nil, fmt.Errorf("tx body message %d has invalid type url %q", i, message.TypeUrl)
+		}
+	}
+
+	authInfo := &pbcosmos.AuthInfo{}
+	if err := unmarshalOptions.Unmarshal(raw.AuthInfoBytes, authInfo); err != nil {
+		return nil, nil, nil, fmt.Errorf("decoding tx auth info: %w", err)
+	}
+
+	return body, authInfo, raw.Signatures, nil
+}
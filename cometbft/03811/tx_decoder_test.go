@@ -0,0 +1,136 @@
+package v03811
+
+import (
+	"encoding/hex"
+	"testing"
+
+	cometType "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// the test txs are encoded field by field, the way the Cosmos SDK encodes them, rather than
+// through the decoded message types
+func appendBytesField(b []byte, num protowire.Number, value []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, value)
+}
+
+func appendVarintField(b []byte, num protowire.Number, value uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, value)
+}
+
+func testAny(typeURL string, value []byte) []byte {
+	return appendBytesField(appendBytesField(nil, 1, []byte(typeURL)), 2, value)
+}
+
+func testTxBody(messages ...[]byte) []byte {
+	var body []byte
+	for _, message := range messages {
+		body = appendBytesField(body, 1, message)
+	}
+	body = appendBytesField(body, 2, []byte("memo"))
+	return appendVarintField(body, 3, 120)
+}
+
+func testAuthInfo() []byte {
+	pubKey := testAny("/cosmos.crypto.secp256k1.PubKey", appendBytesField(nil, 1, make([]byte, 33)))
+	modeInfo := appendBytesField(nil, 1, appendVarintField(nil, 1, 1))
+	signerInfo := appendVarintField(appendBytesField(appendBytesField(nil, 1, pubKey), 2, modeInfo), 3, 7)
+
+	coin := appendBytesField(appendBytesField(nil, 1, []byte("uatom")), 2, []byte("5000"))
+	fee := appendVarintField(appendBytesField(nil, 1, coin), 2, 200_000)
+
+	return appendBytesField(appendBytesField(nil, 1, signerInfo), 2, fee)
+}
+
+func testTxRaw(body []byte, authInfo []byte) []byte {
+	raw := appendBytesField(nil, 1, body)
+	raw = appendBytesField(raw, 2, authInfo)
+	return appendBytesField(raw, 3, make([]byte, 64))
+}
+
+func TestDecodeTx(t *testing.T) {
+	msgSend := testAny("/cosmos.bank.v1beta1.MsgSend", appendBytesField(nil, 1, []byte("cosmos1sender")))
+
+	evmTx, err := hex.DecodeString("f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83")
+	require.NoError(t, err)
+
+	cases := []struct {
+		name        string
+		tx          []byte
+		wantDecoded bool
+		wantErr     string
+	}{
+		{
+			name:        "sdk tx",
+			tx:          testTxRaw(testTxBody(msgSend), testAuthInfo()),
+			wantDecoded: true,
+		},
+		{
+			name: "unknown body and auth info fields of later sdk versions",
+			tx: testTxRaw(
+				appendVarintField(testTxBody(msgSend), 4, 1),
+				appendBytesField(testAuthInfo(), 3, []byte("tip")),
+			),
+			wantDecoded: true,
+		},
+		{
+			name:    "evm tx",
+			tx:      evmTx,
+			wantErr: "decoding tx raw",
+		},
+		{
+			name:    "not protobuf",
+			tx:      []byte{0xff, 0xff, 0xff},
+			wantErr: "decoding tx raw",
+		},
+		{
+			name:    "unknown tx raw field",
+			tx:      appendBytesField(testTxRaw(testTxBody(msgSend), testAuthInfo()), 4, []byte("unknown")),
+			wantErr: "decoding tx raw: unknown fields",
+		},
+		{
+			name:    "no message",
+			tx:      testTxRaw(testTxBody(), testAuthInfo()),
+			wantErr: "tx body has no message",
+		},
+		{
+			name:    "type url without slash",
+			tx:      testTxRaw(testTxBody(testAny("cosmos.bank.v1beta1.MsgSend", nil)), testAuthInfo()),
+			wantErr: "tx body message 0 has invalid type url",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tx := cometType.Tx(c.tx)
+			decoded := decodeTx(tx)
+			require.Equal(t, tx.Hash(), decoded.Hash)
+
+			if !c.wantDecoded {
+				require.Contains(t, decoded.DecodeError, c.wantErr)
+				require.Equal(t, c.tx, decoded.Raw)
+				require.Nil(t, decoded.Body)
+				require.Nil(t, decoded.AuthInfo)
+				return
+			}
+
+			require.Empty(t, decoded.DecodeError)
+			require.Nil(t, decoded.Raw)
+			require.Len(t, decoded.Body.Messages, 1)
+			require.Equal(t, "/cosmos.bank.v1beta1.MsgSend", decoded.Body.Messages[0].TypeUrl)
+			require.Equal(t, "memo", decoded.Body.Memo)
+			require.Equal(t, uint64(120), decoded.Body.TimeoutHeight)
+			require.Len(t, decoded.AuthInfo.SignerInfos, 1)
+			require.Equal(t, uint64(7), decoded.AuthInfo.SignerInfos[0].Sequence)
+			require.Equal(t, "/cosmos.crypto.secp256k1.PubKey", decoded.AuthInfo.SignerInfos[0].PublicKey.TypeUrl)
+			require.Equal(t, uint64(200_000), decoded.AuthInfo.Fee.GasLimit)
+			require.Equal(t, "uatom", decoded.AuthInfo.Fee.Amount[0].Denom)
+			require.Equal(t, [][]byte{make([]byte, 64)}, decoded.Signatures)
+			require.Empty(t, decoded.Body.ProtoReflect().GetUnknown())
+			require.Empty(t, decoded.AuthInfo.ProtoReflect().GetUnknown())
+		})
+	}
+}
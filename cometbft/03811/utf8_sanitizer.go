@@ -0,0 +1,91 @@
+package v03811
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+)
+
+type UTF8Policy string
+
+const (
+	// UTF8PolicyReplace replaces each invalid byte by U+FFFD
+	UTF8PolicyReplace UTF8Policy = "replace"
+	// UTF8PolicyKeepRaw replaces each invalid byte by U+FFFD and keeps the original bytes in the
+	// `raw_*` field next to the string
+	UTF8PolicyKeepRaw UTF8Policy = "keep-raw"
+	// UTF8PolicyFail refuses to convert a block holding a string that is not valid UTF-8
+	UTF8PolicyFail UTF8Policy = "fail"
+)
+
+func ParseUTF8Policy(in string) (UTF8Policy, error) {
+	switch policy := UTF8Policy(in); policy {
+	case UTF8PolicyReplace, UTF8PolicyKeepRaw, UTF8PolicyFail:
+		return policy, nil
+	}
+	return "", fmt.Errorf("invalid UTF-8 policy %q, expected %q, %q or %q", in, UTF8PolicyReplace, UTF8PolicyKeepRaw, UTF8PolicyFail)
+}
+
+// maxReportedLocations bounds the number of locations logged for a single block.
+const maxReportedLocations = 20
+
+// utf8Sanitizer makes the free form strings of the abci results valid UTF-8, which proto3
+// `string` fields require, and records where it had to. A new one is used for each block.
+type utf8Sanitizer struct {
+	policy    UTF8Policy
+	count     int
+	locations []string
+}
+
+func newUTF8Sanitizer(policy UTF8Policy) *utf8Sanitizer {
+	if policy == "" {
+		policy = UTF8PolicyReplace
+	}
+	return &utf8Sanitizer{policy: policy}
+}
+
+// sanitize returns the string to store and whether it was not valid UTF-8. The field is the
+// metric label, the location is only formatted when the string is invalid.
+func (s *utf8Sanitizer) sanitize(in string, field string, locationFormat string, args ...any) (string, bool, error) {
+	if utf8.ValidString(in) {
+		return in, false, nil
+	}
+
+	invalidUTF8Strings.Inc(field)
+	location := fmt.Sprintf(locationFormat, args...)
+	if s.policy == UTF8PolicyFail {
+		return "", true, fmt.Errorf("%s is not valid UTF-8: %q", location, in)
+	}
+
+	s.count++
+	if len(s.locations) < maxReportedLocations {
+		s.locations = append(s.locations, location)
+	}
+
+	// ranging over a string decodes each invalid byte as utf8.RuneError, written back as U+FFFD
+	var out strings.Builder
+	out.Grow(len(in))
+	for _, r := range in {
+		out.WriteRune(r)
+	}
+	return out.String(), true, nil
+}
+
+func (s *utf8Sanitizer) keepRaw() bool {
+	return s.policy == UTF8PolicyKeepRaw
+}
+
+func (s *utf8Sanitizer) report(blockNum int64, logger *zap.Logger) {
+	if s.count == 0 {
+		return
+	}
+
+	logger.Warn("replaced strings that are not valid UTF-8",
+		zap.Int64("block_num", blockNum),
+		zap.Int("count", s.count),
+		zap.Strings("locations", s.locations),
+		zap.String("policy", string(s.policy)),
+	)
+}
@@ -0,0 +1,91 @@
+package v03811
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestConvertBlock_InvalidUTF8(t *testing.T) {
+	storage := newTestChainStorage(t, 10, 10)
+
+	const invalid = "amount\xff"
+	const replaced = "amount�"
+
+	cases := []struct {
+		name    string
+		options ConversionOptions
+		wantRaw bool
+		// wantRawAttribute is set when only the attribute keys and values keep their bytes
+		wantRawAttribute bool
+		wantErr          string
+	}{
+		{name: "replace", options: ConversionOptions{UTF8Policy: UTF8PolicyReplace}},
+		{name: "default policy replaces", options: ConversionOptions{}},
+		{name: "keep raw", options: ConversionOptions{UTF8Policy: UTF8PolicyKeepRaw}, wantRaw: true, wantRawAttribute: true},
+		{name: "lossless event attributes", options: ConversionOptions{LosslessEventAttributes: true}, wantRawAttribute: true},
+		{name: "fail", options: ConversionOptions{UTF8Policy: UTF8PolicyFail}, wantErr: "tx_results[0].events[0].type is not valid UTF-8"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rpcBlock, rpcBlockResults := storage.rpcResponses(t, 10)
+			txResult := rpcBlockResults.TxsResults[0]
+			txResult.Log = invalid
+			txResult.Events[0].Type = invalid
+			txResult.Events[0].Attributes[0].Key = invalid
+			txResult.Events[0].Attributes[0].Value = invalid
+
+			block, err := convertBlock(rpcBlock, rpcBlockResults, "rpc", c.options, zap.NewNop())
+			if c.wantErr != "" {
+				require.ErrorContains(t, err, c.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			converted := block.TxResults[0]
+			require.Equal(t, replaced, converted.Log)
+			require.Equal(t, replaced, converted.Events[0].Type)
+			require.Equal(t, replaced, converted.Events[0].Attributes[0].Key)
+			require.Equal(t, replaced, converted.Events[0].Attributes[0].Value)
+
+			var wantRaw, wantRawAttribute []byte
+			if c.wantRaw {
+				wantRaw = []byte(invalid)
+			}
+			if c.wantRawAttribute {
+				wantRawAttribute = []byte(invalid)
+			}
+			require.Equal(t, wantRaw, converted.RawLog)
+			require.Equal(t, wantRaw, converted.Events[0].RawType)
+			require.Equal(t, wantRawAttribute, converted.Events[0].Attributes[0].RawKey)
+			require.Equal(t, wantRawAttribute, converted.Events[0].Attributes[0].RawValue)
+
+			// valid strings are left untouched and never get raw bytes
+			require.Equal(t, "info", converted.Info)
+			require.Nil(t, converted.RawInfo)
+		})
+	}
+}
+
+// TestConvertBlock_InvalidUTF8OverRPC documents why the raw bytes are only recovered from chain
+// storage: the node JSON encoder replaces invalid UTF-8 before the response reaches the fetcher.
+func TestConvertBlock_InvalidUTF8OverRPC(t *testing.T) {
+	storage := newTestChainStorage(t, 10, 10)
+	storage.responses[10].TxResults[0].Events[0].Attributes[0].Value = "amount\xff"
+	require.NoError(t, storage.stateStore.SaveFinalizeBlockResponse(10, storage.responses[10]))
+
+	options := ConversionOptions{UTF8Policy: UTF8PolicyKeepRaw, LosslessEventAttributes: true}
+
+	rpcBlock, rpcBlockResults := storage.rpcResponses(t, 10)
+	fetched, err := convertBlock(rpcBlock, rpcBlockResults, "rpc", options, zap.NewNop())
+	require.NoError(t, err)
+	require.Equal(t, "amount�", fetched.TxResults[0].Events[0].Attributes[0].Value)
+	require.Nil(t, fetched.TxResults[0].Events[0].Attributes[0].RawValue)
+
+	loaded, err := NewLoader(storage.blockStore, storage.stateStore, nil, options, zap.NewNop()).loadBlock(10)
+	require.NoError(t, err)
+	require.Equal(t, "amount�", loaded.TxResults[0].Events[0].Attributes[0].Value)
+	require.Equal(t, []byte("amount\xff"), loaded.TxResults[0].Events[0].Attributes[0].RawValue)
+}
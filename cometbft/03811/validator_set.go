@@ -0,0 +1,110 @@
+package v03811
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	cometType "github.com/cometbft/cometbft/types"
+	pbcosmos "github.com/streamingfast/firehose-cosmos/cosmos/pb/sf/cosmos/type/v2"
+	"go.uber.org/zap"
+)
+
+const validatorsPerPage = 100
+
+// maxProposerPriorityIncrements is the distance above which the validator set is fetched again
+// rather than rotating the proposer priorities of the cached one.
+const maxProposerPriorityIncrements = 1000
+
+// ValidatorSetSnapshotter embeds the validator set of each block. The set is only fetched again
+// when the header `ValidatorsHash` changes, in between the proposer priorities are rotated
+// locally the same way CometBFT does from one height to the next.
+type ValidatorSetSnapshotter struct {
+	logger *zap.Logger
+
+	lock   sync.Mutex
+	height int64
+	set    *cometType.ValidatorSet
+}
+
+func NewValidatorSetSnapshotter(logger *zap.Logger) *ValidatorSetSnapshotter {
+	return &ValidatorSetSnapshotter{
+		logger: logger,
+	}
+}
+
+func (s *ValidatorSetSnapshotter) snapshot(ctx context.Context, wrappedClient *CometHttpClientWrap, header *cometType.Header) (*pbcosmos.ValidatorSet, error) {
+	set, err := s.validatorSet(ctx, wrappedClient, header)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pbcosmos.ValidatorSet{
+		Validators:       make([]*pbcosmos.ValidatorInfo, len(set.Validators)),
+		TotalVotingPower: set.TotalVotingPower(),
+	}
+	for i, validator := range set.Validators {
+		protoValidator, err := validator.ToProto()
+		if err != nil {
+			return nil, fmt.Errorf("validator %s: %w", validator.Address, err)
+		}
+		out.Validators[i] = &pbcosmos.ValidatorInfo{}
+		if err := protoFlip(protoValidator, out.Validators[i]); err != nil {
+			return nil, fmt.Errorf("converting validator %s: %w", validator.Address, err)
+		}
+	}
+	return out, nil
+}
+
+func (s *ValidatorSetSnapshotter) validatorSet(ctx context.Context, wrappedClient *CometHttpClientWrap, header *cometType.Header) (*cometType.ValidatorSet, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	distance := header.Height - s.height
+	if s.set != nil && bytes.Equal(s.set.Hash(), header.ValidatorsHash) && distance >= 0 && distance <= maxProposerPriorityIncrements {
+		if distance > 0 {
+			s.set = s.set.CopyIncrementProposerPriority(int32(distance))
+			s.height = header.Height
+		}
+		return s.set, nil
+	}
+
+	s.logger.Debug("fetching validator set", zap.Int64("block_num", header.Height), zap.Stringer("validators_hash", header.ValidatorsHash), zap.String("rpc_endpoint", wrappedClient.endpoint))
+	set, err := fetchValidatorSet(ctx, wrappedClient, header.Height)
+	if err != nil {
+		return nil, fmt.Errorf("fetching validator set of block %d: %w", header.Height, err)
+	}
+	if !bytes.Equal(set.Hash(), header.ValidatorsHash) {
+		return nil, fmt.Errorf("validator set of block %d served by %q has hash %s, header validators hash is %s", header.Height, wrappedClient.endpoint, set.Hash(), header.ValidatorsHash)
+	}
+	validatorSetFetches.Inc()
+
+	s.height = header.Height
+	s.set = set
+	return set, nil
+}
+
+// fetchValidatorSet fetches every page of the validator set of a height.
+func fetchValidatorSet(ctx context.Context, wrappedClient *CometHttpClientWrap, height int64) (*cometType.ValidatorSet, error) {
+	var validators []*cometType.Validator
+	for page := 1; ; page++ {
+		perPage := validatorsPerPage
+		result, err := wrappedClient.cometHttpClient.Validators(ctx, &height, &page, &perPage)
+		if err != nil {
+			err = wrappedClient.redactError(err)
+			return nil, fmt.Errorf("fetching validators page %d: %w", page, err)
+		}
+		validators = append(validators, result.Validators...)
+		if len(result.Validators) == 0 || len(validators) >= result.Total {
+			break
+		}
+	}
+
+	// validators are sorted by voting power, the order hashed in the header
+	validatorSet, err := cometType.ValidatorSetFromExistingValidators(validators)
+	if err != nil {
+		return nil, fmt.Errorf("validators of block %d: %w", height, err)
+	}
+	return validatorSet, nil
+}
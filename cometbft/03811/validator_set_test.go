@@ -0,0 +1,155 @@
+package v03811
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	cometType "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testValidatorsServer serves `/validators` pages of the validator set returned by setAt for a
+// height, the `<height> <page>` pages requested are recorded.
+type testValidatorsServer struct {
+	*httptest.Server
+
+	setAt func(height int64) *cometType.ValidatorSet
+
+	lock  sync.Mutex
+	pages []string
+}
+
+func newTestValidatorsServer(t *testing.T, setAt func(height int64) *cometType.ValidatorSet) *testValidatorsServer {
+	s := &testValidatorsServer{setAt: setAt}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var request types.RPCRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(s.reply(request))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testValidatorsServer) reply(request types.RPCRequest) types.RPCResponse {
+	var params struct {
+		Height  string `json:"height"`
+		Page    string `json:"page"`
+		PerPage string `json:"per_page"`
+	}
+	if err := json.Unmarshal(request.Params, &params); err != nil || request.Method != "validators" {
+		return types.RPCInvalidRequestError(request.ID, fmt.Errorf("unexpected %s call", request.Method))
+	}
+	height, _ := strconv.ParseInt(params.Height, 10, 64)
+	page, _ := strconv.Atoi(params.Page)
+	perPage, _ := strconv.Atoi(params.PerPage)
+
+	s.lock.Lock()
+	s.pages = append(s.pages, fmt.Sprintf("%d %d", height, page))
+	s.lock.Unlock()
+
+	validators := s.setAt(height).Validators
+	start := min((page-1)*perPage, len(validators))
+	end := min(start+perPage, len(validators))
+	return types.NewRPCSuccessResponse(request.ID, &ctypes.ResultValidators{
+		BlockHeight: height,
+		Validators:  validators[start:end],
+		Count:       end - start,
+		Total:       len(validators),
+	})
+}
+
+func (s *testValidatorsServer) requestedPages() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	pages := s.pages
+	s.pages = nil
+	return pages
+}
+
+func testValidatorSet(count int, seed string) *cometType.ValidatorSet {
+	validators := make([]*cometType.Validator, count)
+	for i := range validators {
+		pubKey := ed25519.GenPrivKeyFromSecret([]byte(fmt.Sprintf("%s-%d", seed, i))).PubKey()
+		validators[i] = cometType.NewValidator(pubKey, int64(10+i%7))
+	}
+	return cometType.NewValidatorSet(validators)
+}
+
+func TestValidatorSetSnapshotter_Snapshot(t *testing.T) {
+	const base = 100
+
+	// the set changes at height 5000, proposer priorities rotate at every height like on chain
+	initial := testValidatorSet(150, "initial")
+	changed := testValidatorSet(3, "changed")
+	setAt := func(height int64) *cometType.ValidatorSet {
+		if height >= 5000 {
+			return changed.CopyIncrementProposerPriority(int32(height - 5000 + 1))
+		}
+		return initial.CopyIncrementProposerPriority(int32(height - base + 1))
+	}
+
+	server := newTestValidatorsServer(t, setAt)
+	client, err := NewCometHttpClientWrap(server.URL, EndpointTierRecent, EndpointLimits{}, nil, zap.NewNop())
+	require.NoError(t, err)
+	snapshotter := NewValidatorSetSnapshotter(zap.NewNop())
+
+	cases := []struct {
+		name      string
+		height    int64
+		wantPages []string
+	}{
+		{"first block is fetched over two pages", base, []string{"100 1", "100 2"}},
+		{"next block is rotated locally", base + 1, nil},
+		{"same block again", base + 1, nil},
+		{"up to the rotation cap is rotated locally", base + 1 + maxProposerPriorityIncrements, nil},
+		{"further than the rotation cap is fetched", base + 2 + 2*maxProposerPriorityIncrements, []string{"2102 1", "2102 2"}},
+		{"block before the cached one is fetched", 1500, []string{"1500 1", "1500 2"}},
+		{"validators hash change is fetched", 5000, []string{"5000 1"}},
+		{"rotated after the change", 5010, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			want := setAt(c.height)
+			header := &cometType.Header{Height: c.height, ValidatorsHash: want.Hash()}
+
+			snapshot, err := snapshotter.snapshot(context.Background(), client, header)
+			require.NoError(t, err)
+			require.Equal(t, c.wantPages, server.requestedPages())
+
+			require.Equal(t, want.TotalVotingPower(), snapshot.TotalVotingPower)
+			require.Len(t, snapshot.Validators, len(want.Validators))
+			for i, validator := range want.Validators {
+				require.Equal(t, []byte(validator.Address), snapshot.Validators[i].Address, "validator %d", i)
+				require.Equal(t, validator.VotingPower, snapshot.Validators[i].VotingPower, "validator %d", i)
+				require.Equal(t, validator.ProposerPriority, snapshot.Validators[i].ProposerPriority, "validator %d", i)
+			}
+		})
+	}
+}
+
+func TestValidatorSetSnapshotter_HashMismatch(t *testing.T) {
+	set := testValidatorSet(4, "served")
+	server := newTestValidatorsServer(t, func(height int64) *cometType.ValidatorSet { return set })
+	client, err := NewCometHttpClientWrap(server.URL, EndpointTierRecent, EndpointLimits{}, nil, zap.NewNop())
+	require.NoError(t, err)
+
+	header := &cometType.Header{Height: 10, ValidatorsHash: testValidatorSet(4, "expected").Hash()}
+	_, err = NewValidatorSetSnapshotter(zap.NewNop()).snapshot(context.Background(), client, header)
+	require.ErrorContains(t, err, "header validators hash is")
+}
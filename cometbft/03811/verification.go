@@ -0,0 +1,244 @@
+package v03811
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cometType "github.com/cometbft/cometbft/types"
+	"go.uber.org/zap"
+)
+
+type MismatchPolicy string
+
+const (
+	// MismatchPolicyRefuse refuses to emit a block on which endpoints disagree
+	MismatchPolicyRefuse MismatchPolicy = "refuse"
+	// MismatchPolicyQuarantine emits the block served by a strict majority of endpoints and
+	// quarantines the others, the block is refused when there is no majority
+	MismatchPolicyQuarantine MismatchPolicy = "quarantine"
+)
+
+// Verifier fetches a block from other endpoints and compares what they served with the block
+// about to be emitted, so that a provider serving a stale or forked block or block results is
+// caught. The block hash, the deterministic part of the tx results (the one hashed in the next
+// header `LastResultsHash`) and the events are compared.
+type Verifier struct {
+	router             *EndpointRouter
+	quorum             int
+	sampleRate         float64
+	policy             MismatchPolicy
+	quarantineDuration time.Duration
+	logger             *zap.Logger
+
+	// rotates the endpoint the witnesses are picked from, so that the load is spread on all of them
+	nextWitness atomic.Uint64
+
+	// set by WithVerification, fetches the block from a single endpoint
+	fetchSingle func(ctx context.Context, wrappedClient *CometHttpClientWrap, requestBlockNum uint64) (*fetchedBlock, error)
+}
+
+// NewVerifier creates a verifier requiring quorum endpoints (at least 2, including the one that
+// served the block) to agree on a sampleRate fraction of the blocks.
+func NewVerifier(router *EndpointRouter, quorum int, sampleRate float64, policy MismatchPolicy, quarantineDuration time.Duration, logger *zap.Logger) (*Verifier, error) {
+	if quorum < 2 {
+		return nil, fmt.Errorf("invalid verification quorum %d, at least 2 endpoints are needed", quorum)
+	}
+	if sampleRate <= 0 || sampleRate > 1 {
+		return nil, fmt.Errorf("invalid verification sample rate %f, expected a value in ]0, 1]", sampleRate)
+	}
+	if policy != MismatchPolicyRefuse && policy != MismatchPolicyQuarantine {
+		return nil, fmt.Errorf("invalid verification mismatch policy %q, expected %q or %q", policy, MismatchPolicyRefuse, MismatchPolicyQuarantine)
+	}
+
+	return &Verifier{
+		router:             router,
+		quorum:             quorum,
+		sampleRate:         sampleRate,
+		policy:             policy,
+		quarantineDuration: quarantineDuration,
+		logger:             logger,
+	}, nil
+}
+
+type verifiedResponse struct {
+	client  *CometHttpClientWrap
+	fetched *fetchedBlock
+	digest  blockDigest
+}
+
+// verify returns the block to emit, which is not the fetched one when it was served by a
+// quarantined minority.
+func (v *Verifier) verify(ctx context.Context, fetched *fetchedBlock, requestBlockNum uint64) (*fetchedBlock, error) {
+	if v.sampleRate < 1 && rand.Float64() >= v.sampleRate {
+		return fetched, nil
+	}
+
+	responses := []*verifiedResponse{{client: fetched.blockClient, fetched: fetched, digest: digestFetchedBlock(fetched)}}
+	if fetched.blockResultsClient != fetched.blockClient {
+		// the two halves come from different endpoints, neither of them is a witness for the other
+		responses = nil
+	}
+
+	others, err := v.fetchFromOthers(ctx, fetched, requestBlockNum, v.quorum-len(responses))
+	if err != nil {
+		return nil, fmt.Errorf("verifying block %d: %w", requestBlockNum, err)
+	}
+	responses = append(responses, others...)
+
+	groups := map[blockDigest][]*verifiedResponse{}
+	for _, response := range responses {
+		groups[response.digest] = append(groups[response.digest], response)
+	}
+	if len(groups) == 1 {
+		verifiedBlocks.Inc()
+		return responses[0].fetched, nil
+	}
+
+	verificationMismatches.Inc()
+	var served []string
+	for _, response := range responses {
+		v.logger.Warn("endpoints disagree on block",
+			zap.Uint64("block_num", requestBlockNum),
+			zap.String("rpc_endpoint", response.client.endpoint),
+			zap.String("block_hash", response.digest.blockHash),
+			zap.String("results_hash", response.digest.resultsHash),
+			zap.String("events_hash", response.digest.eventsHash),
+		)
+		served = append(served, fmt.Sprintf("%q served %s", response.client.endpoint, response.digest))
+	}
+	mismatchErr := fmt.Errorf("endpoints disagree on block %d: %s", requestBlockNum, strings.Join(served, ", "))
+
+	if v.policy == MismatchPolicyRefuse {
+		return nil, mismatchErr
+	}
+
+	var majority []*verifiedResponse
+	for _, group := range groups {
+		if len(group)*2 > len(responses) {
+			majority = group
+		}
+	}
+	if majority == nil {
+		return nil, fmt.Errorf("no majority: %w", mismatchErr)
+	}
+
+	for _, response := range responses {
+		if response.digest == majority[0].digest {
+			continue
+		}
+		v.logger.Warn("quarantining endpoint serving a block disagreeing with the majority", zap.String("rpc_endpoint", response.client.endpoint), zap.Uint64("block_num", requestBlockNum), zap.Duration("quarantine_duration", v.quarantineDuration))
+		response.client.Quarantine(v.quarantineDuration)
+		quarantinedEndpoints.Inc()
+	}
+
+	return majority[0].fetched, nil
+}
+
+// fetchFromOthers fetches the block from count healthy endpoints that did not serve it, moving
+// on to the next endpoint when one fails. Each call starts from the candidate following the one
+// the previous call started from.
+func (v *Verifier) fetchFromOthers(ctx context.Context, fetched *fetchedBlock, requestBlockNum uint64, count int) ([]*verifiedResponse, error) {
+	var candidates []*CometHttpClientWrap
+	for _, client := range v.router.clients {
+		if client == fetched.blockClient || client == fetched.blockResultsClient {
+			continue
+		}
+		if client.Healthy() && client.HoldsBlock(requestBlockNum) {
+			candidates = append(candidates, client)
+		}
+	}
+	if len(candidates) > 0 {
+		start := int(v.nextWitness.Add(1) % uint64(len(candidates)))
+		candidates = append(candidates[start:], candidates[:start]...)
+	}
+
+	var responses []*verifiedResponse
+	var errs []string
+	for len(responses) < count {
+		missing := count - len(responses)
+		if len(candidates) < missing {
+			return nil, fmt.Errorf("not enough endpoints to verify against, %d more needed: %s", missing, strings.Join(errs, ", "))
+		}
+
+		var lock sync.Mutex
+		var wg sync.WaitGroup
+		for _, client := range candidates[:missing] {
+			wg.Add(1)
+			go func(client *CometHttpClientWrap) {
+				defer wg.Done()
+
+				other, err := v.fetchSingle(ctx, client, requestBlockNum)
+				lock.Lock()
+				defer lock.Unlock()
+				if err != nil {
+					if ctx.Err() == nil {
+						client.recordFailure()
+					}
+					errs = append(errs, fmt.Sprintf("%q failed: %s", client.endpoint, err))
+					return
+				}
+				client.recordSuccess()
+				responses = append(responses, &verifiedResponse{client: client, fetched: other, digest: digestFetchedBlock(other)})
+			}(client)
+		}
+		wg.Wait()
+		candidates = candidates[missing:]
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+
+	return responses, nil
+}
+
+type blockDigest struct {
+	blockHash   string
+	resultsHash string
+	eventsHash  string
+}
+
+func (d blockDigest) String() string {
+	return fmt.Sprintf("block hash %s, results hash %s, events hash %s", d.blockHash, d.resultsHash, d.eventsHash)
+}
+
+// digestFetchedBlock hashes the events on their type and attribute keys and values only, the
+// attribute `index` flag is node configuration and differs between providers serving the same
+// block.
+func digestFetchedBlock(fetched *fetchedBlock) blockDigest {
+	events := sha256.New()
+	writeString := func(value string) {
+		_ = binary.Write(events, binary.BigEndian, uint64(len(value)))
+		events.Write([]byte(value))
+	}
+	writeEvents := func(list []abci.Event) {
+		_ = binary.Write(events, binary.BigEndian, uint64(len(list)))
+		for _, event := range list {
+			writeString(event.Type)
+			_ = binary.Write(events, binary.BigEndian, uint64(len(event.Attributes)))
+			for _, attribute := range event.Attributes {
+				writeString(attribute.Key)
+				writeString(attribute.Value)
+			}
+		}
+	}
+	for _, txResult := range fetched.blockResults.TxsResults {
+		writeEvents(txResult.Events)
+	}
+	writeEvents(fetched.blockResults.FinalizeBlockEvents)
+
+	return blockDigest{
+		blockHash:   fetched.block.BlockID.Hash.String(),
+		resultsHash: hex.EncodeToString(cometType.NewResults(fetched.blockResults.TxsResults).Hash()),
+		eventsHash:  hex.EncodeToString(events.Sum(nil)),
+	}
+}
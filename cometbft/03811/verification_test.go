@@ -0,0 +1,186 @@
+package v03811
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestVerifier_Verify(t *testing.T) {
+	storage := newTestChainStorage(t, 10, 10)
+
+	forEachAttribute := func(fetched *fetchedBlock, apply func(attribute *abci.EventAttribute)) {
+		for _, txResult := range fetched.blockResults.TxsResults {
+			for _, event := range txResult.Events {
+				for i := range event.Attributes {
+					apply(&event.Attributes[i])
+				}
+			}
+		}
+		for _, event := range fetched.blockResults.FinalizeBlockEvents {
+			for i := range event.Attributes {
+				apply(&event.Attributes[i])
+			}
+		}
+	}
+	flipIndex := func(attribute *abci.EventAttribute) { attribute.Index = !attribute.Index }
+	fork := func(attribute *abci.EventAttribute) { attribute.Value += "-forked" }
+
+	// client 0 serves the block being verified, the others are witnesses
+	cases := []struct {
+		name    string
+		clients int
+		quorum  int
+		policy  MismatchPolicy
+		alter   map[int]func(attribute *abci.EventAttribute)
+		failing map[int]bool
+
+		wantErr string
+		// whether the block served by a witness is emitted in place of the verified one
+		wantReplaced    bool
+		wantQuarantined []int
+	}{
+		{
+			name:    "same block",
+			clients: 2, quorum: 2, policy: MismatchPolicyRefuse,
+		},
+		{
+			name:    "index flags differ",
+			clients: 2, quorum: 2, policy: MismatchPolicyRefuse,
+			alter: map[int]func(attribute *abci.EventAttribute){1: flipIndex},
+		},
+		{
+			name:    "attribute values differ",
+			clients: 2, quorum: 2, policy: MismatchPolicyRefuse,
+			alter:   map[int]func(attribute *abci.EventAttribute){1: fork},
+			wantErr: "endpoints disagree on block 10",
+		},
+		{
+			name:    "quarantine witness in the minority",
+			clients: 3, quorum: 3, policy: MismatchPolicyQuarantine,
+			alter:           map[int]func(attribute *abci.EventAttribute){2: fork},
+			wantQuarantined: []int{2},
+		},
+		{
+			name:    "quarantine serving endpoint in the minority",
+			clients: 3, quorum: 3, policy: MismatchPolicyQuarantine,
+			alter:           map[int]func(attribute *abci.EventAttribute){0: fork},
+			wantReplaced:    true,
+			wantQuarantined: []int{0},
+		},
+		{
+			name:    "quarantine without majority",
+			clients: 2, quorum: 2, policy: MismatchPolicyQuarantine,
+			alter:   map[int]func(attribute *abci.EventAttribute){1: fork},
+			wantErr: "no majority",
+		},
+		{
+			name:    "failing witness is replaced",
+			clients: 3, quorum: 2, policy: MismatchPolicyRefuse,
+			failing: map[int]bool{1: true, 2: false},
+		},
+		{
+			name:    "not enough witnesses",
+			clients: 3, quorum: 3, policy: MismatchPolicyRefuse,
+			failing: map[int]bool{2: true},
+			wantErr: "not enough endpoints to verify against, 1 more needed",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// providers keying the endpoint with an api key in the path share the same redacted endpoint
+			var clients []*CometHttpClientWrap
+			indexes := map[*CometHttpClientWrap]int{}
+			for i := 0; i < c.clients; i++ {
+				client, err := NewCometHttpClientWrap(fmt.Sprintf("https://rpc.provider.com/key-%d", i), EndpointTierRecent, EndpointLimits{}, nil, zap.NewNop())
+				require.NoError(t, err)
+				client.earliestBlockHeight.Store(1)
+				client.latestBlockHeight.Store(10)
+				clients = append(clients, client)
+				indexes[client] = i
+			}
+			require.Equal(t, clients[0].endpoint, clients[1].endpoint)
+
+			serve := func(client *CometHttpClientWrap) *fetchedBlock {
+				block, blockResults := storage.rpcResponses(t, 10)
+				fetched := &fetchedBlock{block: block, blockClient: client, blockResults: blockResults, blockResultsClient: client}
+				if alter := c.alter[indexes[client]]; alter != nil {
+					forEachAttribute(fetched, alter)
+				}
+				return fetched
+			}
+
+			verifier, err := NewVerifier(NewEndpointRouter(clients, nil, 0), c.quorum, 1, c.policy, time.Minute, zap.NewNop())
+			require.NoError(t, err)
+			verifier.fetchSingle = func(ctx context.Context, wrappedClient *CometHttpClientWrap, requestBlockNum uint64) (*fetchedBlock, error) {
+				if wrappedClient == clients[0] {
+					return nil, errors.New("the serving endpoint must not be its own witness")
+				}
+				if c.failing[indexes[wrappedClient]] {
+					return nil, errors.New("connection refused")
+				}
+				return serve(wrappedClient), nil
+			}
+
+			verified, err := verifier.verify(context.Background(), serve(clients[0]), 10)
+			if c.wantErr != "" {
+				require.ErrorContains(t, err, c.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if c.wantReplaced {
+				require.NotSame(t, clients[0], verified.blockClient)
+			} else {
+				require.Same(t, clients[0], verified.blockClient)
+			}
+
+			var quarantined []int
+			for i, client := range clients {
+				if client.Quarantined() {
+					quarantined = append(quarantined, i)
+				}
+			}
+			require.Equal(t, c.wantQuarantined, quarantined)
+		})
+	}
+}
+
+func TestVerifier_WitnessesRotate(t *testing.T) {
+	storage := newTestChainStorage(t, 10, 10)
+
+	var clients []*CometHttpClientWrap
+	for i := 0; i < 4; i++ {
+		client, err := NewCometHttpClientWrap(fmt.Sprintf("http://node-%d:26657", i), EndpointTierRecent, EndpointLimits{}, nil, zap.NewNop())
+		require.NoError(t, err)
+		client.earliestBlockHeight.Store(1)
+		client.latestBlockHeight.Store(10)
+		clients = append(clients, client)
+	}
+
+	verifier, err := NewVerifier(NewEndpointRouter(clients, nil, 0), 2, 1, MismatchPolicyRefuse, time.Minute, zap.NewNop())
+	require.NoError(t, err)
+	witnesses := map[*CometHttpClientWrap]int{}
+	verifier.fetchSingle = func(ctx context.Context, wrappedClient *CometHttpClientWrap, requestBlockNum uint64) (*fetchedBlock, error) {
+		witnesses[wrappedClient]++
+		block, blockResults := storage.rpcResponses(t, 10)
+		return &fetchedBlock{block: block, blockClient: wrappedClient, blockResults: blockResults, blockResultsClient: wrappedClient}, nil
+	}
+
+	for i := 0; i < 6; i++ {
+		block, blockResults := storage.rpcResponses(t, 10)
+		_, err := verifier.verify(context.Background(), &fetchedBlock{block: block, blockClient: clients[0], blockResults: blockResults, blockResultsClient: clients[0]}, 10)
+		require.NoError(t, err)
+	}
+
+	require.Zero(t, witnesses[clients[0]])
+	for _, client := range clients[1:] {
+		require.Equal(t, 2, witnesses[client], "witness %s", client.endpoint)
+	}
+}
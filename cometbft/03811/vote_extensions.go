@@ -0,0 +1,64 @@
+package v03811
+
+import (
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cometType "github.com/cometbft/cometbft/types"
+	pbcosmos "github.com/streamingfast/firehose-cosmos/cosmos/pb/sf/cosmos/type/v2"
+	"go.uber.org/zap"
+)
+
+type VoteExtensionsSource string
+
+const (
+	// VoteExtensionsSourceInjectedTx reads the extended commit info that the proposer injected as
+	// the first tx of the block, the convention of oracle chains (Skip Connect/Slinky) and dYdX.
+	// CometBFT 0.38 rpc does not expose extended commits otherwise.
+	VoteExtensionsSourceInjectedTx VoteExtensionsSource = "injected-tx"
+)
+
+func ParseVoteExtensionsSource(in string) (VoteExtensionsSource, error) {
+	if source := VoteExtensionsSource(in); source == VoteExtensionsSourceInjectedTx {
+		return source, nil
+	}
+	return "", fmt.Errorf("invalid vote extensions source %q, expected %q", in, VoteExtensionsSourceInjectedTx)
+}
+
+// extractExtendedCommitInfo returns the extended commit info injected in the block, nil when the
+// block does not start with one.
+func extractExtendedCommitInfo(block *cometType.Block, logger *zap.Logger) (*pbcosmos.ExtendedCommitInfo, error) {
+	if len(block.Txs) == 0 {
+		return nil, nil
+	}
+
+	info := &abci.ExtendedCommitInfo{}
+	if err := info.Unmarshal(block.Txs[0]); err != nil || !looksLikeExtendedCommitInfo(info) {
+		logger.Debug("first tx of block is not an extended commit info", zap.Int64("block_num", block.Height), zap.Error(err))
+		return nil, nil
+	}
+
+	out := &pbcosmos.ExtendedCommitInfo{}
+	if err := protoFlip(info, out); err != nil {
+		return nil, fmt.Errorf("converting extended commit info: %w", err)
+	}
+	return out, nil
+}
+
+// looksLikeExtendedCommitInfo guards against regular txs that happen to decode, every vote must
+// come from a validator address with a known block id flag.
+func looksLikeExtendedCommitInfo(info *abci.ExtendedCommitInfo) bool {
+	if len(info.Votes) == 0 || info.Round < 0 {
+		return false
+	}
+
+	for _, vote := range info.Votes {
+		if len(vote.Validator.Address) != 20 || vote.Validator.Power <= 0 {
+			return false
+		}
+		if _, found := pbcosmos.BlockIDFlag_name[int32(vote.BlockIdFlag)]; !found || vote.BlockIdFlag == 0 {
+			return false
+		}
+	}
+	return true
+}
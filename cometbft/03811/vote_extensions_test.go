@@ -0,0 +1,52 @@
+package v03811
+
+import (
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestConvertBlock_VoteExtensions(t *testing.T) {
+	storage := newTestChainStorage(t, 10, 10)
+
+	info := &abci.ExtendedCommitInfo{
+		Round: 1,
+		Votes: []abci.ExtendedVoteInfo{{
+			Validator:     abci.Validator{Address: make([]byte, 20), Power: 10},
+			VoteExtension: []byte("prices"),
+			BlockIdFlag:   cmtproto.BlockIDFlagCommit,
+		}},
+	}
+	injected, err := info.Marshal()
+	require.NoError(t, err)
+
+	cases := []struct {
+		name    string
+		source  VoteExtensionsSource
+		firstTx []byte
+		want    []byte
+	}{
+		{name: "injected", source: VoteExtensionsSourceInjectedTx, firstTx: injected, want: []byte("prices")},
+		{name: "disabled", firstTx: injected},
+		{name: "regular first tx", source: VoteExtensionsSourceInjectedTx, firstTx: []byte("tx-10-0")},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rpcBlock, rpcBlockResults := storage.rpcResponses(t, 10)
+			rpcBlock.Block.Txs[0] = c.firstTx
+
+			block, err := convertBlock(rpcBlock, rpcBlockResults, "rpc", ConversionOptions{VoteExtensions: c.source}, zap.NewNop())
+			require.NoError(t, err)
+			if c.want == nil {
+				require.Nil(t, block.ExtendedCommitInfo)
+				return
+			}
+			require.Len(t, block.ExtendedCommitInfo.Votes, 1)
+			require.Equal(t, c.want, block.ExtendedCommitInfo.Votes[0].VoteExtension)
+		})
+	}
+}
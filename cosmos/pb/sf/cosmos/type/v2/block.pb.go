@@ -9,6 +9,7 @@ package pbcomos
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
@@ -22,6 +23,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// BlockIDFlag indicates which BlockID the signature is for.
+type BlockIDFlag int32
+
+const (
+	BlockIDFlag_BLOCK_ID_FLAG_UNKNOWN BlockIDFlag = 0
+	BlockIDFlag_BLOCK_ID_FLAG_ABSENT  BlockIDFlag = 1 // no vote was received from a validator
+	BlockIDFlag_BLOCK_ID_FLAG_COMMIT  BlockIDFlag = 2 // voted for the Commit.BlockID
+	BlockIDFlag_BLOCK_ID_FLAG_NIL     BlockIDFlag = 3 // voted for nil
+)
+
+// Enum value maps for BlockIDFlag.
+var (
+	BlockIDFlag_name = map[int32]string{
+		0: "BLOCK_ID_FLAG_UNKNOWN",
+		1: "BLOCK_ID_FLAG_ABSENT",
+		2: "BLOCK_ID_FLAG_COMMIT",
+		3: "BLOCK_ID_FLAG_NIL",
+	}
+	BlockIDFlag_value = map[string]int32{
+		"BLOCK_ID_FLAG_UNKNOWN": 0,
+		"BLOCK_ID_FLAG_ABSENT":  1,
+		"BLOCK_ID_FLAG_COMMIT":  2,
+		"BLOCK_ID_FLAG_NIL":     3,
+	}
+)
+
+func (x BlockIDFlag) Enum() *BlockIDFlag {
+	p := new(BlockIDFlag)
+	*p = x
+	return p
+}
+
+func (x BlockIDFlag) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BlockIDFlag) Descriptor() protoreflect.EnumDescriptor {
+	return file_sf_cosmos_type_v2_block_proto_enumTypes[0].Descriptor()
+}
+
+func (BlockIDFlag) Type() protoreflect.EnumType {
+	return &file_sf_cosmos_type_v2_block_proto_enumTypes[0]
+}
+
+func (x BlockIDFlag) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BlockIDFlag.Descriptor instead.
+func (BlockIDFlag) EnumDescriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{0}
+}
+
 type MisbehaviorType int32
 
 const (
@@ -55,11 +109,11 @@ func (x MisbehaviorType) String() string {
 }
 
 func (MisbehaviorType) Descriptor() protoreflect.EnumDescriptor {
-	return file_sf_cosmos_type_v2_block_proto_enumTypes[0].Descriptor()
+	return file_sf_cosmos_type_v2_block_proto_enumTypes[1].Descriptor()
 }
 
 func (MisbehaviorType) Type() protoreflect.EnumType {
-	return &file_sf_cosmos_type_v2_block_proto_enumTypes[0]
+	return &file_sf_cosmos_type_v2_block_proto_enumTypes[1]
 }
 
 func (x MisbehaviorType) Number() protoreflect.EnumNumber {
@@ -68,7 +122,7 @@ func (x MisbehaviorType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use MisbehaviorType.Descriptor instead.
 func (MisbehaviorType) EnumDescriptor() ([]byte, []int) {
-	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{0}
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{1}
 }
 
 // Firehose-centric Block
@@ -87,6 +141,27 @@ type Block struct {
 	TxResults             []*TxResults           `protobuf:"bytes,9,rep,name=tx_results,json=txResults,proto3" json:"tx_results,omitempty"`
 	ValidatorUpdates      []*ValidatorUpdate     `protobuf:"bytes,10,rep,name=validator_updates,json=validatorUpdates,proto3" json:"validator_updates,omitempty"`
 	ConsensusParamUpdates *ConsensusParams       `protobuf:"bytes,11,opt,name=consensus_param_updates,json=consensusParamUpdates,proto3" json:"consensus_param_updates,omitempty"`
+	// commit of the previous block, as included in this block
+	LastCommit *Commit `protobuf:"bytes,15,opt,name=last_commit,json=lastCommit,proto3" json:"last_commit,omitempty"`
+	// validators of this block, only set when the fetcher embeds them
+	ValidatorSet *ValidatorSet `protobuf:"bytes,16,opt,name=validator_set,json=validatorSet,proto3" json:"validator_set,omitempty"`
+	// app hash resulting from the execution of this block, the header app_hash
+	// is the one resulting from the previous block
+	AppHash []byte `protobuf:"bytes,17,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+	// vote extensions of the previous height as given to the proposer of this
+	// block, only set when the fetcher extracts them
+	ExtendedCommitInfo *ExtendedCommitInfo `protobuf:"bytes,18,opt,name=extended_commit_info,json=extendedCommitInfo,proto3" json:"extended_commit_info,omitempty"`
+	// finalize block events that the Cosmos SDK tagged with the `mode` attribute
+	// `BeginBlock` and `EndBlock`, as they were split up to cometbft 0.37. They
+	// are also part of `events`, which holds every finalize block event
+	BeginBlockEvents []*Event `protobuf:"bytes,19,rep,name=begin_block_events,json=beginBlockEvents,proto3" json:"begin_block_events,omitempty"`
+	EndBlockEvents   []*Event `protobuf:"bytes,20,rep,name=end_block_events,json=endBlockEvents,proto3" json:"end_block_events,omitempty"`
+	// decoded Cosmos SDK envelope of each tx, at the same index as txs and
+	// tx_results, only set when the fetcher decodes them
+	DecodedTxs []*Tx `protobuf:"bytes,21,rep,name=decoded_txs,json=decodedTxs,proto3" json:"decoded_txs,omitempty"`
+	// integrity checks the block failed, only set when the fetcher checks them
+	// with the `flag` policy, which emits such blocks instead of refusing them
+	IntegrityCheckFailures []string `protobuf:"bytes,22,rep,name=integrity_check_failures,json=integrityCheckFailures,proto3" json:"integrity_check_failures,omitempty"`
 }
 
 func (x *Block) Reset() {
@@ -191,6 +266,62 @@ func (x *Block) GetConsensusParamUpdates() *ConsensusParams {
 	return nil
 }
 
+func (x *Block) GetLastCommit() *Commit {
+	if x != nil {
+		return x.LastCommit
+	}
+	return nil
+}
+
+func (x *Block) GetValidatorSet() *ValidatorSet {
+	if x != nil {
+		return x.ValidatorSet
+	}
+	return nil
+}
+
+func (x *Block) GetAppHash() []byte {
+	if x != nil {
+		return x.AppHash
+	}
+	return nil
+}
+
+func (x *Block) GetExtendedCommitInfo() *ExtendedCommitInfo {
+	if x != nil {
+		return x.ExtendedCommitInfo
+	}
+	return nil
+}
+
+func (x *Block) GetBeginBlockEvents() []*Event {
+	if x != nil {
+		return x.BeginBlockEvents
+	}
+	return nil
+}
+
+func (x *Block) GetEndBlockEvents() []*Event {
+	if x != nil {
+		return x.EndBlockEvents
+	}
+	return nil
+}
+
+func (x *Block) GetDecodedTxs() []*Tx {
+	if x != nil {
+		return x.DecodedTxs
+	}
+	return nil
+}
+
+func (x *Block) GetIntegrityCheckFailures() []string {
+	if x != nil {
+		return x.IntegrityCheckFailures
+	}
+	return nil
+}
+
 // Header defines the structure of a block header.
 type Header struct {
 	state         protoimpl.MessageState
@@ -513,26 +644,21 @@ func (x *PartSetHeader) GetHash() []byte {
 	return nil
 }
 
-type Misbehavior struct {
+// Commit contains the evidence that a block was committed by a set of
+// validators.
+type Commit struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type MisbehaviorType `protobuf:"varint,1,opt,name=type,proto3,enum=sf.cosmos.type.v2.MisbehaviorType" json:"type,omitempty"`
-	// The offending validator
-	Validator *Validator `protobuf:"bytes,2,opt,name=validator,proto3" json:"validator,omitempty"`
-	// The height when the offense occurred
-	Height int64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
-	// The corresponding time where the offense occurred
-	Time *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
-	// Total voting power of the validator set in case the ABCI application does
-	// not store historical validators.
-	// https://github.com/tendermint/tendermint/issues/4581
-	TotalVotingPower int64 `protobuf:"varint,5,opt,name=total_voting_power,json=totalVotingPower,proto3" json:"total_voting_power,omitempty"`
+	Height     int64        `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round      int32        `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	BlockId    *BlockID     `protobuf:"bytes,3,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Signatures []*CommitSig `protobuf:"bytes,4,rep,name=signatures,proto3" json:"signatures,omitempty"`
 }
 
-func (x *Misbehavior) Reset() {
-	*x = Misbehavior{}
+func (x *Commit) Reset() {
+	*x = Commit{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -540,13 +666,13 @@ func (x *Misbehavior) Reset() {
 	}
 }
 
-func (x *Misbehavior) String() string {
+func (x *Commit) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Misbehavior) ProtoMessage() {}
+func (*Commit) ProtoMessage() {}
 
-func (x *Misbehavior) ProtoReflect() protoreflect.Message {
+func (x *Commit) ProtoReflect() protoreflect.Message {
 	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -558,57 +684,53 @@ func (x *Misbehavior) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Misbehavior.ProtoReflect.Descriptor instead.
-func (*Misbehavior) Descriptor() ([]byte, []int) {
+// Deprecated: Use Commit.ProtoReflect.Descriptor instead.
+func (*Commit) Descriptor() ([]byte, []int) {
 	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Misbehavior) GetType() MisbehaviorType {
-	if x != nil {
-		return x.Type
-	}
-	return MisbehaviorType_UNKNOWN
-}
-
-func (x *Misbehavior) GetValidator() *Validator {
+func (x *Commit) GetHeight() int64 {
 	if x != nil {
-		return x.Validator
+		return x.Height
 	}
-	return nil
+	return 0
 }
 
-func (x *Misbehavior) GetHeight() int64 {
+func (x *Commit) GetRound() int32 {
 	if x != nil {
-		return x.Height
+		return x.Round
 	}
 	return 0
 }
 
-func (x *Misbehavior) GetTime() *timestamppb.Timestamp {
+func (x *Commit) GetBlockId() *BlockID {
 	if x != nil {
-		return x.Time
+		return x.BlockId
 	}
 	return nil
 }
 
-func (x *Misbehavior) GetTotalVotingPower() int64 {
+func (x *Commit) GetSignatures() []*CommitSig {
 	if x != nil {
-		return x.TotalVotingPower
+		return x.Signatures
 	}
-	return 0
+	return nil
 }
 
-type Validator struct {
+// CommitSig is a part of the Vote included in a Commit.
+type CommitSig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"` // The first 20 bytes of SHA256(public key)
-	Power   int64  `protobuf:"varint,3,opt,name=power,proto3" json:"power,omitempty"`    // The voting power
+	BlockIdFlag      BlockIDFlag            `protobuf:"varint,1,opt,name=block_id_flag,json=blockIdFlag,proto3,enum=sf.cosmos.type.v2.BlockIDFlag" json:"block_id_flag,omitempty"`
+	ValidatorAddress []byte                 `protobuf:"bytes,2,opt,name=validator_address,json=validatorAddress,proto3" json:"validator_address,omitempty"`
+	Timestamp        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Signature        []byte                 `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
-func (x *Validator) Reset() {
-	*x = Validator{}
+func (x *CommitSig) Reset() {
+	*x = CommitSig{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -616,13 +738,13 @@ func (x *Validator) Reset() {
 	}
 }
 
-func (x *Validator) String() string {
+func (x *CommitSig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Validator) ProtoMessage() {}
+func (*CommitSig) ProtoMessage() {}
 
-func (x *Validator) ProtoReflect() protoreflect.Message {
+func (x *CommitSig) ProtoReflect() protoreflect.Message {
 	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -634,36 +756,52 @@ func (x *Validator) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Validator.ProtoReflect.Descriptor instead.
-func (*Validator) Descriptor() ([]byte, []int) {
+// Deprecated: Use CommitSig.ProtoReflect.Descriptor instead.
+func (*CommitSig) Descriptor() ([]byte, []int) {
 	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *Validator) GetAddress() []byte {
+func (x *CommitSig) GetBlockIdFlag() BlockIDFlag {
 	if x != nil {
-		return x.Address
+		return x.BlockIdFlag
+	}
+	return BlockIDFlag_BLOCK_ID_FLAG_UNKNOWN
+}
+
+func (x *CommitSig) GetValidatorAddress() []byte {
+	if x != nil {
+		return x.ValidatorAddress
 	}
 	return nil
 }
 
-func (x *Validator) GetPower() int64 {
+func (x *CommitSig) GetTimestamp() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Power
+		return x.Timestamp
 	}
-	return 0
+	return nil
 }
 
-type Event struct {
+func (x *CommitSig) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// ExtendedCommitInfo is the commit of the previous height along with the vote
+// extensions of its precommits.
+type ExtendedCommitInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type       string            `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Attributes []*EventAttribute `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	Round int32               `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Votes []*ExtendedVoteInfo `protobuf:"bytes,2,rep,name=votes,proto3" json:"votes,omitempty"`
 }
 
-func (x *Event) Reset() {
-	*x = Event{}
+func (x *ExtendedCommitInfo) Reset() {
+	*x = ExtendedCommitInfo{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -671,13 +809,13 @@ func (x *Event) Reset() {
 	}
 }
 
-func (x *Event) String() string {
+func (x *ExtendedCommitInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Event) ProtoMessage() {}
+func (*ExtendedCommitInfo) ProtoMessage() {}
 
-func (x *Event) ProtoReflect() protoreflect.Message {
+func (x *ExtendedCommitInfo) ProtoReflect() protoreflect.Message {
 	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -689,36 +827,38 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Event.ProtoReflect.Descriptor instead.
-func (*Event) Descriptor() ([]byte, []int) {
+// Deprecated: Use ExtendedCommitInfo.ProtoReflect.Descriptor instead.
+func (*ExtendedCommitInfo) Descriptor() ([]byte, []int) {
 	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *Event) GetType() string {
+func (x *ExtendedCommitInfo) GetRound() int32 {
 	if x != nil {
-		return x.Type
+		return x.Round
 	}
-	return ""
+	return 0
 }
 
-func (x *Event) GetAttributes() []*EventAttribute {
+func (x *ExtendedCommitInfo) GetVotes() []*ExtendedVoteInfo {
 	if x != nil {
-		return x.Attributes
+		return x.Votes
 	}
 	return nil
 }
 
-type EventAttribute struct {
+type ExtendedVoteInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"` //  bool   index = 3;  // nondeterministic
+	Validator          *Validator  `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator,omitempty"`
+	VoteExtension      []byte      `protobuf:"bytes,3,opt,name=vote_extension,json=voteExtension,proto3" json:"vote_extension,omitempty"`                // non-deterministic extension provided by the sending validator's application
+	ExtensionSignature []byte      `protobuf:"bytes,4,opt,name=extension_signature,json=extensionSignature,proto3" json:"extension_signature,omitempty"` // vote extension signature created by CometBFT
+	BlockIdFlag        BlockIDFlag `protobuf:"varint,5,opt,name=block_id_flag,json=blockIdFlag,proto3,enum=sf.cosmos.type.v2.BlockIDFlag" json:"block_id_flag,omitempty"`
 }
 
-func (x *EventAttribute) Reset() {
-	*x = EventAttribute{}
+func (x *ExtendedVoteInfo) Reset() {
+	*x = ExtendedVoteInfo{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -726,13 +866,13 @@ func (x *EventAttribute) Reset() {
 	}
 }
 
-func (x *EventAttribute) String() string {
+func (x *ExtendedVoteInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EventAttribute) ProtoMessage() {}
+func (*ExtendedVoteInfo) ProtoMessage() {}
 
-func (x *EventAttribute) ProtoReflect() protoreflect.Message {
+func (x *ExtendedVoteInfo) ProtoReflect() protoreflect.Message {
 	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -744,36 +884,59 @@ func (x *EventAttribute) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EventAttribute.ProtoReflect.Descriptor instead.
-func (*EventAttribute) Descriptor() ([]byte, []int) {
+// Deprecated: Use ExtendedVoteInfo.ProtoReflect.Descriptor instead.
+func (*ExtendedVoteInfo) Descriptor() ([]byte, []int) {
 	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *EventAttribute) GetKey() string {
+func (x *ExtendedVoteInfo) GetValidator() *Validator {
 	if x != nil {
-		return x.Key
+		return x.Validator
 	}
-	return ""
+	return nil
 }
 
-func (x *EventAttribute) GetValue() string {
+func (x *ExtendedVoteInfo) GetVoteExtension() []byte {
 	if x != nil {
-		return x.Value
+		return x.VoteExtension
 	}
-	return ""
+	return nil
+}
+
+func (x *ExtendedVoteInfo) GetExtensionSignature() []byte {
+	if x != nil {
+		return x.ExtensionSignature
+	}
+	return nil
+}
+
+func (x *ExtendedVoteInfo) GetBlockIdFlag() BlockIDFlag {
+	if x != nil {
+		return x.BlockIdFlag
+	}
+	return BlockIDFlag_BLOCK_ID_FLAG_UNKNOWN
 }
 
-type EventBytes struct {
+type Misbehavior struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type       string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Attributes []*EventAttributeBytes `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	Type MisbehaviorType `protobuf:"varint,1,opt,name=type,proto3,enum=sf.cosmos.type.v2.MisbehaviorType" json:"type,omitempty"`
+	// The offending validator
+	Validator *Validator `protobuf:"bytes,2,opt,name=validator,proto3" json:"validator,omitempty"`
+	// The height when the offense occurred
+	Height int64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	// The corresponding time where the offense occurred
+	Time *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	// Total voting power of the validator set in case the ABCI application does
+	// not store historical validators.
+	// https://github.com/tendermint/tendermint/issues/4581
+	TotalVotingPower int64 `protobuf:"varint,5,opt,name=total_voting_power,json=totalVotingPower,proto3" json:"total_voting_power,omitempty"`
 }
 
-func (x *EventBytes) Reset() {
-	*x = EventBytes{}
+func (x *Misbehavior) Reset() {
+	*x = Misbehavior{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -781,13 +944,13 @@ func (x *EventBytes) Reset() {
 	}
 }
 
-func (x *EventBytes) String() string {
+func (x *Misbehavior) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EventBytes) ProtoMessage() {}
+func (*Misbehavior) ProtoMessage() {}
 
-func (x *EventBytes) ProtoReflect() protoreflect.Message {
+func (x *Misbehavior) ProtoReflect() protoreflect.Message {
 	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -799,36 +962,57 @@ func (x *EventBytes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EventBytes.ProtoReflect.Descriptor instead.
-func (*EventBytes) Descriptor() ([]byte, []int) {
+// Deprecated: Use Misbehavior.ProtoReflect.Descriptor instead.
+func (*Misbehavior) Descriptor() ([]byte, []int) {
 	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *EventBytes) GetType() string {
+func (x *Misbehavior) GetType() MisbehaviorType {
 	if x != nil {
 		return x.Type
 	}
-	return ""
+	return MisbehaviorType_UNKNOWN
 }
 
-func (x *EventBytes) GetAttributes() []*EventAttributeBytes {
+func (x *Misbehavior) GetValidator() *Validator {
 	if x != nil {
-		return x.Attributes
+		return x.Validator
+	}
+	return nil
+}
+
+func (x *Misbehavior) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Misbehavior) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
 	}
 	return nil
 }
 
-type EventAttributeBytes struct {
+func (x *Misbehavior) GetTotalVotingPower() int64 {
+	if x != nil {
+		return x.TotalVotingPower
+	}
+	return 0
+}
+
+type Validator struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key   []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"` //  bool   index = 3;  // nondeterministic
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"` // The first 20 bytes of SHA256(public key)
+	Power   int64  `protobuf:"varint,3,opt,name=power,proto3" json:"power,omitempty"`    // The voting power
 }
 
-func (x *EventAttributeBytes) Reset() {
-	*x = EventAttributeBytes{}
+func (x *Validator) Reset() {
+	*x = Validator{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -836,13 +1020,13 @@ func (x *EventAttributeBytes) Reset() {
 	}
 }
 
-func (x *EventAttributeBytes) String() string {
+func (x *Validator) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EventAttributeBytes) ProtoMessage() {}
+func (*Validator) ProtoMessage() {}
 
-func (x *EventAttributeBytes) ProtoReflect() protoreflect.Message {
+func (x *Validator) ProtoReflect() protoreflect.Message {
 	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -854,42 +1038,36 @@ func (x *EventAttributeBytes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EventAttributeBytes.ProtoReflect.Descriptor instead.
-func (*EventAttributeBytes) Descriptor() ([]byte, []int) {
+// Deprecated: Use Validator.ProtoReflect.Descriptor instead.
+func (*Validator) Descriptor() ([]byte, []int) {
 	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *EventAttributeBytes) GetKey() []byte {
+func (x *Validator) GetAddress() []byte {
 	if x != nil {
-		return x.Key
+		return x.Address
 	}
 	return nil
 }
 
-func (x *EventAttributeBytes) GetValue() []byte {
+func (x *Validator) GetPower() int64 {
 	if x != nil {
-		return x.Value
+		return x.Power
 	}
-	return nil
+	return 0
 }
 
-type TxResults struct {
+type ValidatorSet struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Code      uint32   `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Data      []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	Log       string   `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`   // nondeterministic
-	Info      string   `protobuf:"bytes,4,opt,name=info,proto3" json:"info,omitempty"` // nondeterministic
-	GasWanted int64    `protobuf:"varint,5,opt,name=gas_wanted,json=gasWanted,proto3" json:"gas_wanted,omitempty"`
-	GasUsed   int64    `protobuf:"varint,6,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
-	Events    []*Event `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"` // nondeterministic
-	Codespace string   `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	Validators       []*ValidatorInfo `protobuf:"bytes,1,rep,name=validators,proto3" json:"validators,omitempty"`
+	TotalVotingPower int64            `protobuf:"varint,2,opt,name=total_voting_power,json=totalVotingPower,proto3" json:"total_voting_power,omitempty"`
 }
 
-func (x *TxResults) Reset() {
-	*x = TxResults{}
+func (x *ValidatorSet) Reset() {
+	*x = ValidatorSet{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -897,13 +1075,13 @@ func (x *TxResults) Reset() {
 	}
 }
 
-func (x *TxResults) String() string {
+func (x *ValidatorSet) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TxResults) ProtoMessage() {}
+func (*ValidatorSet) ProtoMessage() {}
 
-func (x *TxResults) ProtoReflect() protoreflect.Message {
+func (x *ValidatorSet) ProtoReflect() protoreflect.Message {
 	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -915,63 +1093,980 @@ func (x *TxResults) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TxResults.ProtoReflect.Descriptor instead.
-func (*TxResults) Descriptor() ([]byte, []int) {
-	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ValidatorSet.ProtoReflect.Descriptor instead.
+func (*ValidatorSet) Descriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ValidatorSet) GetValidators() []*ValidatorInfo {
+	if x != nil {
+		return x.Validators
+	}
+	return nil
+}
+
+func (x *ValidatorSet) GetTotalVotingPower() int64 {
+	if x != nil {
+		return x.TotalVotingPower
+	}
+	return 0
+}
+
+type ValidatorInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address          []byte     `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"` // The first 20 bytes of SHA256(public key)
+	PubKey           *PublicKey `protobuf:"bytes,2,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	VotingPower      int64      `protobuf:"varint,3,opt,name=voting_power,json=votingPower,proto3" json:"voting_power,omitempty"`
+	ProposerPriority int64      `protobuf:"varint,4,opt,name=proposer_priority,json=proposerPriority,proto3" json:"proposer_priority,omitempty"`
+}
+
+func (x *ValidatorInfo) Reset() {
+	*x = ValidatorInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorInfo) ProtoMessage() {}
+
+func (x *ValidatorInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorInfo.ProtoReflect.Descriptor instead.
+func (*ValidatorInfo) Descriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ValidatorInfo) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *ValidatorInfo) GetPubKey() *PublicKey {
+	if x != nil {
+		return x.PubKey
+	}
+	return nil
+}
+
+func (x *ValidatorInfo) GetVotingPower() int64 {
+	if x != nil {
+		return x.VotingPower
+	}
+	return 0
+}
+
+func (x *ValidatorInfo) GetProposerPriority() int64 {
+	if x != nil {
+		return x.ProposerPriority
+	}
+	return 0
+}
+
+type Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type       string            `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Attributes []*EventAttribute `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	// original bytes of type when it is not valid UTF-8 (type then holds a
+	// sanitised copy), only set with the keep-raw UTF-8 policy
+	RawType []byte `protobuf:"bytes,3,opt,name=raw_type,json=rawType,proto3" json:"raw_type,omitempty"`
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetAttributes() []*EventAttribute {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Event) GetRawType() []byte {
+	if x != nil {
+		return x.RawType
+	}
+	return nil
+}
+
+type EventAttribute struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// whether the attribute is indexed by the node, only set in lossless mode
+	Index bool `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	// original bytes of key and value when they are not valid UTF-8 (key and
+	// value then hold a sanitised copy), only set in lossless mode or with the
+	// keep-raw UTF-8 policy
+	RawKey   []byte `protobuf:"bytes,4,opt,name=raw_key,json=rawKey,proto3" json:"raw_key,omitempty"`
+	RawValue []byte `protobuf:"bytes,5,opt,name=raw_value,json=rawValue,proto3" json:"raw_value,omitempty"`
+}
+
+func (x *EventAttribute) Reset() {
+	*x = EventAttribute{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventAttribute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventAttribute) ProtoMessage() {}
+
+func (x *EventAttribute) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventAttribute.ProtoReflect.Descriptor instead.
+func (*EventAttribute) Descriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *EventAttribute) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *EventAttribute) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *EventAttribute) GetIndex() bool {
+	if x != nil {
+		return x.Index
+	}
+	return false
+}
+
+func (x *EventAttribute) GetRawKey() []byte {
+	if x != nil {
+		return x.RawKey
+	}
+	return nil
+}
+
+func (x *EventAttribute) GetRawValue() []byte {
+	if x != nil {
+		return x.RawValue
+	}
+	return nil
+}
+
+type TxResults struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code      uint32   `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Data      []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Log       string   `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`   // nondeterministic
+	Info      string   `protobuf:"bytes,4,opt,name=info,proto3" json:"info,omitempty"` // nondeterministic
+	GasWanted int64    `protobuf:"varint,5,opt,name=gas_wanted,json=gasWanted,proto3" json:"gas_wanted,omitempty"`
+	GasUsed   int64    `protobuf:"varint,6,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	Events    []*Event `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"` // nondeterministic
+	Codespace string   `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	// original bytes of log, info and codespace when they are not valid UTF-8
+	// (the strings then hold a sanitised copy), only set with the keep-raw UTF-8
+	// policy
+	RawLog       []byte `protobuf:"bytes,9,opt,name=raw_log,json=rawLog,proto3" json:"raw_log,omitempty"`
+	RawInfo      []byte `protobuf:"bytes,10,opt,name=raw_info,json=rawInfo,proto3" json:"raw_info,omitempty"`
+	RawCodespace []byte `protobuf:"bytes,11,opt,name=raw_codespace,json=rawCodespace,proto3" json:"raw_codespace,omitempty"`
+}
+
+func (x *TxResults) Reset() {
+	*x = TxResults{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TxResults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxResults) ProtoMessage() {}
+
+func (x *TxResults) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxResults.ProtoReflect.Descriptor instead.
+func (*TxResults) Descriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TxResults) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *TxResults) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *TxResults) GetLog() string {
+	if x != nil {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *TxResults) GetInfo() string {
+	if x != nil {
+		return x.Info
+	}
+	return ""
+}
+
+func (x *TxResults) GetGasWanted() int64 {
+	if x != nil {
+		return x.GasWanted
+	}
+	return 0
+}
+
+func (x *TxResults) GetGasUsed() int64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
+}
+
+func (x *TxResults) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *TxResults) GetCodespace() string {
+	if x != nil {
+		return x.Codespace
+	}
+	return ""
+}
+
+func (x *TxResults) GetRawLog() []byte {
+	if x != nil {
+		return x.RawLog
+	}
+	return nil
+}
+
+func (x *TxResults) GetRawInfo() []byte {
+	if x != nil {
+		return x.RawInfo
+	}
+	return nil
+}
+
+func (x *TxResults) GetRawCodespace() []byte {
+	if x != nil {
+		return x.RawCodespace
+	}
+	return nil
+}
+
+// Tx is the decoded Cosmos SDK envelope of a tx. When the tx is not a Cosmos
+// SDK tx (such as an Injective EVM tx), only hash, raw and decode_error are set.
+type Tx struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash        []byte    `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"` // sha256 of the tx bytes
+	Body        *TxBody   `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	AuthInfo    *AuthInfo `protobuf:"bytes,3,opt,name=auth_info,json=authInfo,proto3" json:"auth_info,omitempty"`
+	Signatures  [][]byte  `protobuf:"bytes,4,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	Raw         []byte    `protobuf:"bytes,5,opt,name=raw,proto3" json:"raw,omitempty"` // tx bytes, only set when they could not be decoded
+	DecodeError string    `protobuf:"bytes,6,opt,name=decode_error,json=decodeError,proto3" json:"decode_error,omitempty"`
+}
+
+func (x *Tx) Reset() {
+	*x = Tx{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Tx) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tx) ProtoMessage() {}
+
+func (x *Tx) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tx.ProtoReflect.Descriptor instead.
+func (*Tx) Descriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Tx) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *Tx) GetBody() *TxBody {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+func (x *Tx) GetAuthInfo() *AuthInfo {
+	if x != nil {
+		return x.AuthInfo
+	}
+	return nil
+}
+
+func (x *Tx) GetSignatures() [][]byte {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+func (x *Tx) GetRaw() []byte {
+	if x != nil {
+		return x.Raw
+	}
+	return nil
+}
+
+func (x *Tx) GetDecodeError() string {
+	if x != nil {
+		return x.DecodeError
+	}
+	return ""
+}
+
+type TxRaw struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BodyBytes     []byte   `protobuf:"bytes,1,opt,name=body_bytes,json=bodyBytes,proto3" json:"body_bytes,omitempty"`
+	AuthInfoBytes []byte   `protobuf:"bytes,2,opt,name=auth_info_bytes,json=authInfoBytes,proto3" json:"auth_info_bytes,omitempty"`
+	Signatures    [][]byte `protobuf:"bytes,3,rep,name=signatures,proto3" json:"signatures,omitempty"`
+}
+
+func (x *TxRaw) Reset() {
+	*x = TxRaw{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TxRaw) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxRaw) ProtoMessage() {}
+
+func (x *TxRaw) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxRaw.ProtoReflect.Descriptor instead.
+func (*TxRaw) Descriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TxRaw) GetBodyBytes() []byte {
+	if x != nil {
+		return x.BodyBytes
+	}
+	return nil
+}
+
+func (x *TxRaw) GetAuthInfoBytes() []byte {
+	if x != nil {
+		return x.AuthInfoBytes
+	}
+	return nil
+}
+
+func (x *TxRaw) GetSignatures() [][]byte {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+type TxBody struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Messages                    []*anypb.Any `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	Memo                        string       `protobuf:"bytes,2,opt,name=memo,proto3" json:"memo,omitempty"`
+	TimeoutHeight               uint64       `protobuf:"varint,3,opt,name=timeout_height,json=timeoutHeight,proto3" json:"timeout_height,omitempty"`
+	ExtensionOptions            []*anypb.Any `protobuf:"bytes,1023,rep,name=extension_options,json=extensionOptions,proto3" json:"extension_options,omitempty"`
+	NonCriticalExtensionOptions []*anypb.Any `protobuf:"bytes,2047,rep,name=non_critical_extension_options,json=nonCriticalExtensionOptions,proto3" json:"non_critical_extension_options,omitempty"`
+}
+
+func (x *TxBody) Reset() {
+	*x = TxBody{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TxBody) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxBody) ProtoMessage() {}
+
+func (x *TxBody) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxBody.ProtoReflect.Descriptor instead.
+func (*TxBody) Descriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TxBody) GetMessages() []*anypb.Any {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *TxBody) GetMemo() string {
+	if x != nil {
+		return x.Memo
+	}
+	return ""
+}
+
+func (x *TxBody) GetTimeoutHeight() uint64 {
+	if x != nil {
+		return x.TimeoutHeight
+	}
+	return 0
+}
+
+func (x *TxBody) GetExtensionOptions() []*anypb.Any {
+	if x != nil {
+		return x.ExtensionOptions
+	}
+	return nil
+}
+
+func (x *TxBody) GetNonCriticalExtensionOptions() []*anypb.Any {
+	if x != nil {
+		return x.NonCriticalExtensionOptions
+	}
+	return nil
+}
+
+type AuthInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SignerInfos []*SignerInfo `protobuf:"bytes,1,rep,name=signer_infos,json=signerInfos,proto3" json:"signer_infos,omitempty"`
+	Fee         *Fee          `protobuf:"bytes,2,opt,name=fee,proto3" json:"fee,omitempty"`
+}
+
+func (x *AuthInfo) Reset() {
+	*x = AuthInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuthInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthInfo) ProtoMessage() {}
+
+func (x *AuthInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthInfo.ProtoReflect.Descriptor instead.
+func (*AuthInfo) Descriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AuthInfo) GetSignerInfos() []*SignerInfo {
+	if x != nil {
+		return x.SignerInfos
+	}
+	return nil
+}
+
+func (x *AuthInfo) GetFee() *Fee {
+	if x != nil {
+		return x.Fee
+	}
+	return nil
+}
+
+type SignerInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PublicKey *anypb.Any `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	ModeInfo  *ModeInfo  `protobuf:"bytes,2,opt,name=mode_info,json=modeInfo,proto3" json:"mode_info,omitempty"`
+	Sequence  uint64     `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+}
+
+func (x *SignerInfo) Reset() {
+	*x = SignerInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignerInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignerInfo) ProtoMessage() {}
+
+func (x *SignerInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignerInfo.ProtoReflect.Descriptor instead.
+func (*SignerInfo) Descriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SignerInfo) GetPublicKey() *anypb.Any {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *SignerInfo) GetModeInfo() *ModeInfo {
+	if x != nil {
+		return x.ModeInfo
+	}
+	return nil
+}
+
+func (x *SignerInfo) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type ModeInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Sum:
+	//	*ModeInfo_Single_
+	//	*ModeInfo_Multi_
+	Sum isModeInfo_Sum `protobuf_oneof:"sum"`
+}
+
+func (x *ModeInfo) Reset() {
+	*x = ModeInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModeInfo) ProtoMessage() {}
+
+func (x *ModeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModeInfo.ProtoReflect.Descriptor instead.
+func (*ModeInfo) Descriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{21}
+}
+
+func (m *ModeInfo) GetSum() isModeInfo_Sum {
+	if m != nil {
+		return m.Sum
+	}
+	return nil
+}
+
+func (x *ModeInfo) GetSingle() *ModeInfo_Single {
+	if x, ok := x.GetSum().(*ModeInfo_Single_); ok {
+		return x.Single
+	}
+	return nil
+}
+
+func (x *ModeInfo) GetMulti() *ModeInfo_Multi {
+	if x, ok := x.GetSum().(*ModeInfo_Multi_); ok {
+		return x.Multi
+	}
+	return nil
+}
+
+type isModeInfo_Sum interface {
+	isModeInfo_Sum()
+}
+
+type ModeInfo_Single_ struct {
+	Single *ModeInfo_Single `protobuf:"bytes,1,opt,name=single,proto3,oneof"`
+}
+
+type ModeInfo_Multi_ struct {
+	Multi *ModeInfo_Multi `protobuf:"bytes,2,opt,name=multi,proto3,oneof"`
+}
+
+func (*ModeInfo_Single_) isModeInfo_Sum() {}
+
+func (*ModeInfo_Multi_) isModeInfo_Sum() {}
+
+type CompactBitArray struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExtraBitsStored uint32 `protobuf:"varint,1,opt,name=extra_bits_stored,json=extraBitsStored,proto3" json:"extra_bits_stored,omitempty"`
+	Elems           []byte `protobuf:"bytes,2,opt,name=elems,proto3" json:"elems,omitempty"`
+}
+
+func (x *CompactBitArray) Reset() {
+	*x = CompactBitArray{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompactBitArray) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompactBitArray) ProtoMessage() {}
+
+func (x *CompactBitArray) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompactBitArray.ProtoReflect.Descriptor instead.
+func (*CompactBitArray) Descriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *TxResults) GetCode() uint32 {
+func (x *CompactBitArray) GetExtraBitsStored() uint32 {
 	if x != nil {
-		return x.Code
+		return x.ExtraBitsStored
 	}
 	return 0
 }
 
-func (x *TxResults) GetData() []byte {
+func (x *CompactBitArray) GetElems() []byte {
 	if x != nil {
-		return x.Data
+		return x.Elems
 	}
 	return nil
 }
 
-func (x *TxResults) GetLog() string {
-	if x != nil {
-		return x.Log
+type Fee struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Amount   []*Coin `protobuf:"bytes,1,rep,name=amount,proto3" json:"amount,omitempty"`
+	GasLimit uint64  `protobuf:"varint,2,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	Payer    string  `protobuf:"bytes,3,opt,name=payer,proto3" json:"payer,omitempty"`
+	Granter  string  `protobuf:"bytes,4,opt,name=granter,proto3" json:"granter,omitempty"`
+}
+
+func (x *Fee) Reset() {
+	*x = Fee{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *TxResults) GetInfo() string {
+func (x *Fee) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fee) ProtoMessage() {}
+
+func (x *Fee) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fee.ProtoReflect.Descriptor instead.
+func (*Fee) Descriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Fee) GetAmount() []*Coin {
 	if x != nil {
-		return x.Info
+		return x.Amount
 	}
-	return ""
+	return nil
 }
 
-func (x *TxResults) GetGasWanted() int64 {
+func (x *Fee) GetGasLimit() uint64 {
 	if x != nil {
-		return x.GasWanted
+		return x.GasLimit
 	}
 	return 0
 }
 
-func (x *TxResults) GetGasUsed() int64 {
+func (x *Fee) GetPayer() string {
 	if x != nil {
-		return x.GasUsed
+		return x.Payer
 	}
-	return 0
+	return ""
 }
 
-func (x *TxResults) GetEvents() []*Event {
+func (x *Fee) GetGranter() string {
 	if x != nil {
-		return x.Events
+		return x.Granter
 	}
-	return nil
+	return ""
 }
 
-func (x *TxResults) GetCodespace() string {
+type Coin struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Denom  string `protobuf:"bytes,1,opt,name=denom,proto3" json:"denom,omitempty"`
+	Amount string `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *Coin) Reset() {
+	*x = Coin{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Coin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coin) ProtoMessage() {}
+
+func (x *Coin) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coin.ProtoReflect.Descriptor instead.
+func (*Coin) Descriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Coin) GetDenom() string {
 	if x != nil {
-		return x.Codespace
+		return x.Denom
+	}
+	return ""
+}
+
+func (x *Coin) GetAmount() string {
+	if x != nil {
+		return x.Amount
 	}
 	return ""
 }
@@ -989,7 +2084,7 @@ type ValidatorUpdate struct {
 func (x *ValidatorUpdate) Reset() {
 	*x = ValidatorUpdate{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[12]
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1002,7 +2097,7 @@ func (x *ValidatorUpdate) String() string {
 func (*ValidatorUpdate) ProtoMessage() {}
 
 func (x *ValidatorUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[12]
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1015,7 +2110,7 @@ func (x *ValidatorUpdate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValidatorUpdate.ProtoReflect.Descriptor instead.
 func (*ValidatorUpdate) Descriptor() ([]byte, []int) {
-	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{12}
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *ValidatorUpdate) GetPubKey() *PublicKey {
@@ -1038,7 +2133,6 @@ type PublicKey struct {
 	unknownFields protoimpl.UnknownFields
 
 	// Types that are assignable to Sum:
-	//
 	//	*PublicKey_Ed25519
 	//	*PublicKey_Secp256K1
 	Sum isPublicKey_Sum `protobuf_oneof:"sum"`
@@ -1047,7 +2141,7 @@ type PublicKey struct {
 func (x *PublicKey) Reset() {
 	*x = PublicKey{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[13]
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1060,7 +2154,7 @@ func (x *PublicKey) String() string {
 func (*PublicKey) ProtoMessage() {}
 
 func (x *PublicKey) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[13]
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1073,7 +2167,7 @@ func (x *PublicKey) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublicKey.ProtoReflect.Descriptor instead.
 func (*PublicKey) Descriptor() ([]byte, []int) {
-	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{13}
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{26}
 }
 
 func (m *PublicKey) GetSum() isPublicKey_Sum {
@@ -1122,12 +2216,13 @@ type ConsensusParams struct {
 	Evidence  *EvidenceParams  `protobuf:"bytes,2,opt,name=evidence,proto3" json:"evidence,omitempty"`
 	Validator *ValidatorParams `protobuf:"bytes,3,opt,name=validator,proto3" json:"validator,omitempty"`
 	Version   *VersionParams   `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	Abci      *ABCIParams      `protobuf:"bytes,5,opt,name=abci,proto3" json:"abci,omitempty"`
 }
 
 func (x *ConsensusParams) Reset() {
 	*x = ConsensusParams{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[14]
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1140,7 +2235,7 @@ func (x *ConsensusParams) String() string {
 func (*ConsensusParams) ProtoMessage() {}
 
 func (x *ConsensusParams) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[14]
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1153,7 +2248,7 @@ func (x *ConsensusParams) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConsensusParams.ProtoReflect.Descriptor instead.
 func (*ConsensusParams) Descriptor() ([]byte, []int) {
-	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{14}
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *ConsensusParams) GetBlock() *BlockParams {
@@ -1184,6 +2279,13 @@ func (x *ConsensusParams) GetVersion() *VersionParams {
 	return nil
 }
 
+func (x *ConsensusParams) GetAbci() *ABCIParams {
+	if x != nil {
+		return x.Abci
+	}
+	return nil
+}
+
 // BlockParams contains limits on the block size.
 type BlockParams struct {
 	state         protoimpl.MessageState
@@ -1201,7 +2303,7 @@ type BlockParams struct {
 func (x *BlockParams) Reset() {
 	*x = BlockParams{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[15]
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1214,7 +2316,7 @@ func (x *BlockParams) String() string {
 func (*BlockParams) ProtoMessage() {}
 
 func (x *BlockParams) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[15]
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1227,7 +2329,7 @@ func (x *BlockParams) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlockParams.ProtoReflect.Descriptor instead.
 func (*BlockParams) Descriptor() ([]byte, []int) {
-	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{15}
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *BlockParams) GetMaxBytes() int64 {
@@ -1261,8 +2363,8 @@ type EvidenceParams struct {
 	// mechanism for handling [Nothing-At-Stake
 	// attacks](https://github.com/ethereum/wiki/wiki/Proof-of-Stake-FAQ#what-is-the-nothing-at-stake-problem-and-how-can-it-be-fixed).
 	MaxAgeDuration *durationpb.Duration `protobuf:"bytes,2,opt,name=max_age_duration,json=maxAgeDuration,proto3" json:"max_age_duration,omitempty"`
-	// This sets the maximum size of total evidence in bytes that can be committed in a single block.
-	// and should fall comfortably under the max block bytes.
+	// This sets the maximum size of total evidence in bytes that can be committed
+	// in a single block. and should fall comfortably under the max block bytes.
 	// Default is 1048576 or 1MB
 	MaxBytes int64 `protobuf:"varint,3,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
 }
@@ -1270,7 +2372,7 @@ type EvidenceParams struct {
 func (x *EvidenceParams) Reset() {
 	*x = EvidenceParams{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[16]
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1283,7 +2385,7 @@ func (x *EvidenceParams) String() string {
 func (*EvidenceParams) ProtoMessage() {}
 
 func (x *EvidenceParams) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[16]
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1296,7 +2398,7 @@ func (x *EvidenceParams) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EvidenceParams.ProtoReflect.Descriptor instead.
 func (*EvidenceParams) Descriptor() ([]byte, []int) {
-	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{16}
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *EvidenceParams) GetMaxAgeNumBlocks() int64 {
@@ -1333,7 +2435,7 @@ type ValidatorParams struct {
 func (x *ValidatorParams) Reset() {
 	*x = ValidatorParams{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[17]
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1346,7 +2448,7 @@ func (x *ValidatorParams) String() string {
 func (*ValidatorParams) ProtoMessage() {}
 
 func (x *ValidatorParams) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[17]
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1357,44 +2459,208 @@ func (x *ValidatorParams) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValidatorParams.ProtoReflect.Descriptor instead.
-func (*ValidatorParams) Descriptor() ([]byte, []int) {
-	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ValidatorParams.ProtoReflect.Descriptor instead.
+func (*ValidatorParams) Descriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ValidatorParams) GetPubKeyTypes() []string {
+	if x != nil {
+		return x.PubKeyTypes
+	}
+	return nil
+}
+
+// VersionParams contains the ABCI application version.
+type VersionParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	App uint64 `protobuf:"varint,1,opt,name=app,proto3" json:"app,omitempty"`
+}
+
+func (x *VersionParams) Reset() {
+	*x = VersionParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VersionParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionParams) ProtoMessage() {}
+
+func (x *VersionParams) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionParams.ProtoReflect.Descriptor instead.
+func (*VersionParams) Descriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *VersionParams) GetApp() uint64 {
+	if x != nil {
+		return x.App
+	}
+	return 0
+}
+
+// ABCIParams configure functionality specific to the Application Blockchain
+// Interface.
+type ABCIParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// vote_extensions_enable_height configures the first height during which
+	// vote extensions will be enabled. During this specified height, and for all
+	// subsequent heights, precommit messages that do not contain valid extension
+	// data will be considered invalid. Prior to this height, vote extensions will
+	// not be used or accepted by validators on the network.
+	//
+	// Once enabled, vote extensions will be created by the application in
+	// ExtendVote, passed to the application for validation in
+	// VerifyVoteExtension and given to the application to use when proposing a
+	// block during PrepareProposal.
+	VoteExtensionsEnableHeight int64 `protobuf:"varint,1,opt,name=vote_extensions_enable_height,json=voteExtensionsEnableHeight,proto3" json:"vote_extensions_enable_height,omitempty"`
+}
+
+func (x *ABCIParams) Reset() {
+	*x = ABCIParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ABCIParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ABCIParams) ProtoMessage() {}
+
+func (x *ABCIParams) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ABCIParams.ProtoReflect.Descriptor instead.
+func (*ABCIParams) Descriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ABCIParams) GetVoteExtensionsEnableHeight() int64 {
+	if x != nil {
+		return x.VoteExtensionsEnableHeight
+	}
+	return 0
+}
+
+// HashedParams is a subset of ConsensusParams.
+//
+// It is hashed into the Header.ConsensusHash.
+type HashedParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockMaxBytes int64 `protobuf:"varint,1,opt,name=block_max_bytes,json=blockMaxBytes,proto3" json:"block_max_bytes,omitempty"`
+	BlockMaxGas   int64 `protobuf:"varint,2,opt,name=block_max_gas,json=blockMaxGas,proto3" json:"block_max_gas,omitempty"`
+}
+
+func (x *HashedParams) Reset() {
+	*x = HashedParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HashedParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashedParams) ProtoMessage() {}
+
+func (x *HashedParams) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashedParams.ProtoReflect.Descriptor instead.
+func (*HashedParams) Descriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *HashedParams) GetBlockMaxBytes() int64 {
+	if x != nil {
+		return x.BlockMaxBytes
+	}
+	return 0
 }
 
-func (x *ValidatorParams) GetPubKeyTypes() []string {
+func (x *HashedParams) GetBlockMaxGas() int64 {
 	if x != nil {
-		return x.PubKeyTypes
+		return x.BlockMaxGas
 	}
-	return nil
+	return 0
 }
 
-// VersionParams contains the ABCI application version.
-type VersionParams struct {
+type ModeInfo_Single struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	App uint64 `protobuf:"varint,1,opt,name=app,proto3" json:"app,omitempty"`
+	Mode int32 `protobuf:"varint,1,opt,name=mode,proto3" json:"mode,omitempty"` // cosmos.tx.signing.v1beta1.SignMode
 }
 
-func (x *VersionParams) Reset() {
-	*x = VersionParams{}
+func (x *ModeInfo_Single) Reset() {
+	*x = ModeInfo_Single{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[18]
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *VersionParams) String() string {
+func (x *ModeInfo_Single) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VersionParams) ProtoMessage() {}
+func (*ModeInfo_Single) ProtoMessage() {}
 
-func (x *VersionParams) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[18]
+func (x *ModeInfo_Single) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1405,47 +2671,44 @@ func (x *VersionParams) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VersionParams.ProtoReflect.Descriptor instead.
-func (*VersionParams) Descriptor() ([]byte, []int) {
-	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ModeInfo_Single.ProtoReflect.Descriptor instead.
+func (*ModeInfo_Single) Descriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{21, 0}
 }
 
-func (x *VersionParams) GetApp() uint64 {
+func (x *ModeInfo_Single) GetMode() int32 {
 	if x != nil {
-		return x.App
+		return x.Mode
 	}
 	return 0
 }
 
-// HashedParams is a subset of ConsensusParams.
-//
-// It is hashed into the Header.ConsensusHash.
-type HashedParams struct {
+type ModeInfo_Multi struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BlockMaxBytes int64 `protobuf:"varint,1,opt,name=block_max_bytes,json=blockMaxBytes,proto3" json:"block_max_bytes,omitempty"`
-	BlockMaxGas   int64 `protobuf:"varint,2,opt,name=block_max_gas,json=blockMaxGas,proto3" json:"block_max_gas,omitempty"`
+	Bitarray  *CompactBitArray `protobuf:"bytes,1,opt,name=bitarray,proto3" json:"bitarray,omitempty"`
+	ModeInfos []*ModeInfo      `protobuf:"bytes,2,rep,name=mode_infos,json=modeInfos,proto3" json:"mode_infos,omitempty"`
 }
 
-func (x *HashedParams) Reset() {
-	*x = HashedParams{}
+func (x *ModeInfo_Multi) Reset() {
+	*x = ModeInfo_Multi{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[19]
+		mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *HashedParams) String() string {
+func (x *ModeInfo_Multi) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HashedParams) ProtoMessage() {}
+func (*ModeInfo_Multi) ProtoMessage() {}
 
-func (x *HashedParams) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[19]
+func (x *ModeInfo_Multi) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_cosmos_type_v2_block_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1456,23 +2719,23 @@ func (x *HashedParams) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HashedParams.ProtoReflect.Descriptor instead.
-func (*HashedParams) Descriptor() ([]byte, []int) {
-	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use ModeInfo_Multi.ProtoReflect.Descriptor instead.
+func (*ModeInfo_Multi) Descriptor() ([]byte, []int) {
+	return file_sf_cosmos_type_v2_block_proto_rawDescGZIP(), []int{21, 1}
 }
 
-func (x *HashedParams) GetBlockMaxBytes() int64 {
+func (x *ModeInfo_Multi) GetBitarray() *CompactBitArray {
 	if x != nil {
-		return x.BlockMaxBytes
+		return x.Bitarray
 	}
-	return 0
+	return nil
 }
 
-func (x *HashedParams) GetBlockMaxGas() int64 {
+func (x *ModeInfo_Multi) GetModeInfos() []*ModeInfo {
 	if x != nil {
-		return x.BlockMaxGas
+		return x.ModeInfos
 	}
-	return 0
+	return nil
 }
 
 var File_sf_cosmos_type_v2_block_proto protoreflect.FileDescriptor
@@ -1485,130 +2748,224 @@ var file_sf_cosmos_type_v2_block_proto_rawDesc = []byte{
 	0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0x98, 0x04, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x12, 0x0a,
-	0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73,
-	0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x68, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x66, 0x2e, 0x63,
-	0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x48, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x40, 0x0a, 0x0b,
-	0x6d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79,
-	0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f,
-	0x72, 0x52, 0x0b, 0x6d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x12, 0x30,
-	0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
-	0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e,
-	0x76, 0x32, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73,
-	0x12, 0x10, 0x0a, 0x03, 0x74, 0x78, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x03, 0x74,
-	0x78, 0x73, 0x12, 0x3b, 0x0a, 0x0a, 0x74, 0x78, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
-	0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d,
-	0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x54, 0x78, 0x52, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x73, 0x52, 0x09, 0x74, 0x78, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12,
-	0x4f, 0x0a, 0x11, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x75, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x66, 0x2e,
-	0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x56,
-	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x10,
-	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73,
-	0x12, 0x5a, 0x0a, 0x17, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x5f, 0x70, 0x61,
-	0x72, 0x61, 0x6d, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x22, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79,
-	0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x50,
-	0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x15, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73,
-	0x50, 0x61, 0x72, 0x61, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x4a, 0x04, 0x08, 0x0c,
-	0x10, 0x0d, 0x4a, 0x04, 0x08, 0x0d, 0x10, 0x0e, 0x4a, 0x04, 0x08, 0x0e, 0x10, 0x0f, 0x22, 0xc3,
-	0x04, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x36, 0x0a, 0x07, 0x76, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x66, 0x2e,
-	0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x43,
-	0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
-	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65,
-	0x69, 0x67, 0x68, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8c,
+	0x08, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a, 0x06,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04,
-	0x74, 0x69, 0x6d, 0x65, 0x12, 0x3e, 0x0a, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x62, 0x6c, 0x6f,
-	0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x66,
-	0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e,
-	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x42, 0x6c, 0x6f,
-	0x63, 0x6b, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e,
-	0x6c, 0x61, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1b,
-	0x0a, 0x09, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x48, 0x61, 0x73, 0x68, 0x12, 0x27, 0x0a, 0x0f, 0x76,
-	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73,
-	0x48, 0x61, 0x73, 0x68, 0x12, 0x30, 0x0a, 0x14, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x76, 0x61, 0x6c,
-	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x09, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x12, 0x6e, 0x65, 0x78, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
-	0x72, 0x73, 0x48, 0x61, 0x73, 0x68, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e,
-	0x73, 0x75, 0x73, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d,
-	0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x48, 0x61, 0x73, 0x68, 0x12, 0x19, 0x0a,
-	0x08, 0x61, 0x70, 0x70, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x07, 0x61, 0x70, 0x70, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2a, 0x0a, 0x11, 0x6c, 0x61, 0x73, 0x74,
-	0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0c, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
-	0x48, 0x61, 0x73, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65,
-	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x65, 0x76, 0x69,
-	0x64, 0x65, 0x6e, 0x63, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x72, 0x6f,
-	0x70, 0x6f, 0x73, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x0e, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x41, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x22, 0x33, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75,
-	0x73, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x70, 0x70, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x61, 0x70, 0x70, 0x22, 0x67, 0x0a, 0x07, 0x42, 0x6c, 0x6f,
-	0x63, 0x6b, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x48, 0x0a, 0x0f, 0x70, 0x61, 0x72, 0x74,
-	0x5f, 0x73, 0x65, 0x74, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x20, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79,
-	0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x52, 0x0d, 0x70, 0x61, 0x72, 0x74, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64,
-	0x65, 0x72, 0x22, 0x39, 0x0a, 0x0d, 0x50, 0x61, 0x72, 0x74, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73,
-	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0xf7, 0x01,
-	0x0a, 0x0b, 0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x12, 0x36, 0x0a,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x73, 0x66,
-	0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e,
-	0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x3a, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
-	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f,
-	0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x56, 0x61, 0x6c,
-	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
-	0x72, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x6f, 0x74,
-	0x61, 0x6c, 0x5f, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x6f, 0x74, 0x69,
-	0x6e, 0x67, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x22, 0x3b, 0x0a, 0x09, 0x56, 0x61, 0x6c, 0x69, 0x64,
-	0x61, 0x74, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x14,
-	0x0a, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x70,
-	0x6f, 0x77, 0x65, 0x72, 0x22, 0x5e, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x12, 0x41, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f,
-	0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x41,
-	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62,
-	0x75, 0x74, 0x65, 0x73, 0x22, 0x38, 0x0a, 0x0e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x41, 0x74, 0x74,
-	0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x68,
-	0x0a, 0x0a, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x12, 0x46, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73,
-	0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x41, 0x74,
-	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x52, 0x0a, 0x61, 0x74,
-	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x22, 0x3d, 0x0a, 0x13, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xe3, 0x01, 0x0a, 0x09, 0x54, 0x78, 0x52, 0x65,
+	0x74, 0x69, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52,
+	0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x40, 0x0a, 0x0b, 0x6d, 0x69, 0x73, 0x62, 0x65,
+	0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73,
+	0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32,
+	0x2e, 0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x52, 0x0b, 0x6d, 0x69,
+	0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x12, 0x30, 0x0a, 0x06, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x66, 0x2e, 0x63,
+	0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x74,
+	0x78, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x03, 0x74, 0x78, 0x73, 0x12, 0x3b, 0x0a,
+	0x0a, 0x74, 0x78, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x54, 0x78, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52,
+	0x09, 0x74, 0x78, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x4f, 0x0a, 0x11, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18,
+	0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f,
+	0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x6f, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x10, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x5a, 0x0a, 0x17, 0x63,
+	0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x5f, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73,
+	0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32,
+	0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x52, 0x15, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x3a, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73,
+	0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32,
+	0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x12, 0x44, 0x0a, 0x0d, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x5f, 0x73, 0x65, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x66, 0x2e,
+	0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x52, 0x0c, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x70, 0x70,
+	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x70, 0x70,
+	0x48, 0x61, 0x73, 0x68, 0x12, 0x57, 0x0a, 0x14, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64,
+	0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74,
+	0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x12, 0x65, 0x78, 0x74, 0x65, 0x6e,
+	0x64, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x46, 0x0a,
+	0x12, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x66, 0x2e, 0x63,
+	0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x52, 0x10, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x42, 0x0a, 0x10, 0x65, 0x6e, 0x64, 0x5f, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x2e, 0x76, 0x32, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x0e, 0x65, 0x6e, 0x64, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x36, 0x0a, 0x0b, 0x64, 0x65, 0x63,
+	0x6f, 0x64, 0x65, 0x64, 0x5f, 0x74, 0x78, 0x73, 0x18, 0x15, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e,
+	0x76, 0x32, 0x2e, 0x54, 0x78, 0x52, 0x0a, 0x64, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x54, 0x78,
+	0x73, 0x12, 0x38, 0x0a, 0x18, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x16, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x16, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x4a, 0x04, 0x08, 0x0c, 0x10,
+	0x0d, 0x4a, 0x04, 0x08, 0x0d, 0x10, 0x0e, 0x4a, 0x04, 0x08, 0x0e, 0x10, 0x0f, 0x22, 0xc3, 0x04,
+	0x0a, 0x06, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x36, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x66, 0x2e, 0x63,
+	0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x43, 0x6f,
+	0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x19, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x12, 0x3e, 0x0a, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x66, 0x2e,
+	0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x6c,
+	0x61, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1b, 0x0a,
+	0x09, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x48, 0x61, 0x73, 0x68, 0x12, 0x27, 0x0a, 0x0f, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x30, 0x0a, 0x14, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x12, 0x6e, 0x65, 0x78, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x73, 0x48, 0x61, 0x73, 0x68, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73,
+	0x75, 0x73, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x63,
+	0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x48, 0x61, 0x73, 0x68, 0x12, 0x19, 0x0a, 0x08,
+	0x61, 0x70, 0x70, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
+	0x61, 0x70, 0x70, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2a, 0x0a, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x65, 0x76, 0x69, 0x64,
+	0x65, 0x6e, 0x63, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x72, 0x6f, 0x70,
+	0x6f, 0x73, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x22, 0x33, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x70, 0x70, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x03, 0x61, 0x70, 0x70, 0x22, 0x67, 0x0a, 0x07, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x48, 0x0a, 0x0f, 0x70, 0x61, 0x72, 0x74, 0x5f,
+	0x73, 0x65, 0x74, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x2e, 0x76, 0x32, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x52, 0x0d, 0x70, 0x61, 0x72, 0x74, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x22, 0x39, 0x0a, 0x0d, 0x50, 0x61, 0x72, 0x74, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0xab, 0x01, 0x0a,
+	0x06, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x35, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73,
+	0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x49, 0x44, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x3c, 0x0a, 0x0a,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x2e, 0x76, 0x32, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x69, 0x67, 0x52, 0x0a,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x22, 0xd4, 0x01, 0x0a, 0x09, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x69, 0x67, 0x12, 0x42, 0x0a, 0x0d, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x5f, 0x69, 0x64, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1e, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x2e, 0x76, 0x32, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x46, 0x6c, 0x61, 0x67, 0x52,
+	0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x2b, 0x0a, 0x11,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x6f, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x22, 0x65, 0x0a, 0x12, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x39, 0x0a,
+	0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73,
+	0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32,
+	0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x56, 0x6f, 0x74, 0x65, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x22, 0xf0, 0x01, 0x0a, 0x10, 0x45, 0x78, 0x74,
+	0x65, 0x6e, 0x64, 0x65, 0x64, 0x56, 0x6f, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x3a, 0x0a,
+	0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x2e, 0x76, 0x32, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x09,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x25, 0x0a, 0x0e, 0x76, 0x6f, 0x74,
+	0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0d, 0x76, 0x6f, 0x74, 0x65, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x2f, 0x0a, 0x13, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x12, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x12, 0x42, 0x0a, 0x0d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x5f, 0x66, 0x6c,
+	0x61, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f,
+	0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x49, 0x44, 0x46, 0x6c, 0x61, 0x67, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49,
+	0x64, 0x46, 0x6c, 0x61, 0x67, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0xf7, 0x01, 0x0a, 0x0b,
+	0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x12, 0x36, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x73, 0x66, 0x2e, 0x63,
+	0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x4d, 0x69,
+	0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x3a, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d,
+	0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x12,
+	0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x5f, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x6f, 0x74, 0x69, 0x6e, 0x67,
+	0x50, 0x6f, 0x77, 0x65, 0x72, 0x22, 0x3b, 0x0a, 0x09, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x70, 0x6f, 0x77,
+	0x65, 0x72, 0x22, 0x7e, 0x0a, 0x0c, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53,
+	0x65, 0x74, 0x12, 0x40, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d,
+	0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x6f, 0x72, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x76, 0x6f,
+	0x74, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x77,
+	0x65, 0x72, 0x22, 0xb0, 0x01, 0x0a, 0x0d, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x35,
+	0x0a, 0x07, 0x70, 0x75, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x2e, 0x76, 0x32, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x52, 0x06, 0x70,
+	0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x5f,
+	0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x76, 0x6f, 0x74,
+	0x69, 0x6e, 0x67, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x11, 0x70, 0x72, 0x6f, 0x70,
+	0x6f, 0x73, 0x65, 0x72, 0x5f, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x10, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x50, 0x72, 0x69,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x79, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x41, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d,
+	0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x61, 0x77, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x72, 0x61, 0x77, 0x54, 0x79, 0x70, 0x65,
+	0x22, 0x84, 0x01, 0x0a, 0x0e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x61, 0x77, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x72, 0x61, 0x77, 0x4b, 0x65, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x61,
+	0x77, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72,
+	0x61, 0x77, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xbc, 0x02, 0x0a, 0x09, 0x54, 0x78, 0x52, 0x65,
 	0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x0d, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
 	0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a,
@@ -1622,71 +2979,184 @@ var file_sf_cosmos_type_v2_block_proto_rawDesc = []byte{
 	0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76,
 	0x32, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12,
 	0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x5e, 0x0a,
-	0x0f, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x12, 0x35, 0x0a, 0x07, 0x70, 0x75, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79,
-	0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x52,
-	0x06, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x22, 0x4e, 0x0a,
-	0x09, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x07, 0x65, 0x64,
-	0x32, 0x35, 0x35, 0x31, 0x39, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x07, 0x65,
-	0x64, 0x32, 0x35, 0x35, 0x31, 0x39, 0x12, 0x1e, 0x0a, 0x09, 0x73, 0x65, 0x63, 0x70, 0x32, 0x35,
-	0x36, 0x6b, 0x31, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x09, 0x73, 0x65, 0x63,
-	0x70, 0x32, 0x35, 0x36, 0x6b, 0x31, 0x42, 0x05, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x22, 0x84, 0x02,
-	0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d,
-	0x73, 0x12, 0x34, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1e, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70,
-	0x65, 0x2e, 0x76, 0x32, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
-	0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x3d, 0x0a, 0x08, 0x65, 0x76, 0x69, 0x64, 0x65,
-	0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x73, 0x66, 0x2e, 0x63,
-	0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x45, 0x76,
-	0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x08, 0x65, 0x76,
-	0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
-	0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x66, 0x2e, 0x63,
-	0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x56, 0x61,
-	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x09, 0x76,
-	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x3a, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x73, 0x66, 0x2e, 0x63,
-	0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x56, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x07, 0x76, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x22, 0x49, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x50, 0x61, 0x72,
-	0x61, 0x6d, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73,
-	0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f, 0x67, 0x61, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x47, 0x61, 0x73, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x22,
-	0x9f, 0x01, 0x0a, 0x0e, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x72, 0x61,
-	0x6d, 0x73, 0x12, 0x2b, 0x0a, 0x12, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x75,
-	0x6d, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f,
-	0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12,
-	0x43, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x44, 0x75, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x79, 0x74, 0x65,
-	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x42, 0x79, 0x74, 0x65,
-	0x73, 0x22, 0x35, 0x0a, 0x0f, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x50, 0x61,
-	0x72, 0x61, 0x6d, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x70, 0x75, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x5f,
-	0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x75, 0x62,
-	0x4b, 0x65, 0x79, 0x54, 0x79, 0x70, 0x65, 0x73, 0x22, 0x21, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x70, 0x70,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x61, 0x70, 0x70, 0x22, 0x5a, 0x0a, 0x0c, 0x48,
-	0x61, 0x73, 0x68, 0x65, 0x64, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x62,
-	0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4d, 0x61, 0x78, 0x42, 0x79,
-	0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6d, 0x61, 0x78,
-	0x5f, 0x67, 0x61, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63,
-	0x6b, 0x4d, 0x61, 0x78, 0x47, 0x61, 0x73, 0x2a, 0x4b, 0x0a, 0x0f, 0x4d, 0x69, 0x73, 0x62, 0x65,
-	0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e,
-	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x44, 0x55, 0x50, 0x4c, 0x49,
-	0x43, 0x41, 0x54, 0x45, 0x5f, 0x56, 0x4f, 0x54, 0x45, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x4c,
-	0x49, 0x47, 0x48, 0x54, 0x5f, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54, 0x5f, 0x41, 0x54, 0x54, 0x41,
-	0x43, 0x4b, 0x10, 0x02, 0x42, 0x4e, 0x5a, 0x4c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x66, 0x61, 0x73, 0x74,
-	0x2f, 0x66, 0x69, 0x72, 0x65, 0x68, 0x6f, 0x73, 0x65, 0x2d, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73,
-	0x2f, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2f, 0x70, 0x62, 0x2f, 0x73, 0x66, 0x2f, 0x63, 0x6f,
-	0x73, 0x6d, 0x6f, 0x73, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x76, 0x32, 0x3b, 0x70, 0x62, 0x63,
-	0x6f, 0x6d, 0x6f, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x17, 0x0a,
+	0x07, 0x72, 0x61, 0x77, 0x5f, 0x6c, 0x6f, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x72, 0x61, 0x77, 0x4c, 0x6f, 0x67, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x61, 0x77, 0x5f, 0x69, 0x6e,
+	0x66, 0x6f, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x72, 0x61, 0x77, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x61, 0x77, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x72, 0x61, 0x77, 0x43, 0x6f, 0x64,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0xd6, 0x01, 0x0a, 0x02, 0x54, 0x78, 0x12, 0x12, 0x0a,
+	0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73,
+	0x68, 0x12, 0x2d, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x2e, 0x76, 0x32, 0x2e, 0x54, 0x78, 0x42, 0x6f, 0x64, 0x79, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79,
+	0x12, 0x38, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x08, 0x61, 0x75, 0x74, 0x68, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x61,
+	0x77, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x72, 0x61, 0x77, 0x12, 0x21, 0x0a, 0x0c,
+	0x64, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22,
+	0x6e, 0x0a, 0x05, 0x54, 0x78, 0x52, 0x61, 0x77, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6f, 0x64, 0x79,
+	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x6f,
+	0x64, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x61, 0x75, 0x74, 0x68, 0x5f,
+	0x69, 0x6e, 0x66, 0x6f, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0d, 0x61, 0x75, 0x74, 0x68, 0x49, 0x6e, 0x66, 0x6f, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12,
+	0x1e, 0x0a, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x22,
+	0x95, 0x02, 0x0a, 0x06, 0x54, 0x78, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x30, 0x0a, 0x08, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41,
+	0x6e, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x6d, 0x65, 0x6d, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x65, 0x6d, 0x6f,
+	0x12, 0x25, 0x0a, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x68, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x42, 0x0a, 0x11, 0x65, 0x78, 0x74, 0x65, 0x6e,
+	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xff, 0x07, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x10, 0x65, 0x78, 0x74, 0x65, 0x6e,
+	0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x5a, 0x0a, 0x1e, 0x6e,
+	0x6f, 0x6e, 0x5f, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x65, 0x78, 0x74, 0x65,
+	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xff, 0x0f,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x1b, 0x6e, 0x6f, 0x6e, 0x43,
+	0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x76, 0x0a, 0x08, 0x41, 0x75, 0x74, 0x68, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x40, 0x0a, 0x0c, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x6e,
+	0x66, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x66, 0x2e, 0x63,
+	0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x69,
+	0x67, 0x6e, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72,
+	0x49, 0x6e, 0x66, 0x6f, 0x73, 0x12, 0x28, 0x0a, 0x03, 0x66, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74,
+	0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x46, 0x65, 0x65, 0x52, 0x03, 0x66, 0x65, 0x65, 0x22,
+	0x97, 0x01, 0x0a, 0x0a, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x33,
+	0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x4b, 0x65, 0x79, 0x12, 0x38, 0x0a, 0x09, 0x6d, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x6e, 0x66, 0x6f,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d,
+	0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x08, 0x6d, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22, 0xae, 0x02, 0x0a, 0x08, 0x4d, 0x6f,
+	0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x3c, 0x0a, 0x06, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d,
+	0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x49,
+	0x6e, 0x66, 0x6f, 0x2e, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x06, 0x73, 0x69,
+	0x6e, 0x67, 0x6c, 0x65, 0x12, 0x39, 0x0a, 0x05, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f,
+	0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x48, 0x00, 0x52, 0x05, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x1a,
+	0x1c, 0x0a, 0x06, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x1a, 0x83, 0x01,
+	0x0a, 0x05, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x12, 0x3e, 0x0a, 0x08, 0x62, 0x69, 0x74, 0x61, 0x72,
+	0x72, 0x61, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x66, 0x2e, 0x63,
+	0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x43, 0x6f,
+	0x6d, 0x70, 0x61, 0x63, 0x74, 0x42, 0x69, 0x74, 0x41, 0x72, 0x72, 0x61, 0x79, 0x52, 0x08, 0x62,
+	0x69, 0x74, 0x61, 0x72, 0x72, 0x61, 0x79, 0x12, 0x3a, 0x0a, 0x0a, 0x6d, 0x6f, 0x64, 0x65, 0x5f,
+	0x69, 0x6e, 0x66, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x66,
+	0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e,
+	0x4d, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x6d, 0x6f, 0x64, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x73, 0x42, 0x05, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x22, 0x53, 0x0a, 0x0f, 0x43, 0x6f,
+	0x6d, 0x70, 0x61, 0x63, 0x74, 0x42, 0x69, 0x74, 0x41, 0x72, 0x72, 0x61, 0x79, 0x12, 0x2a, 0x0a,
+	0x11, 0x65, 0x78, 0x74, 0x72, 0x61, 0x5f, 0x62, 0x69, 0x74, 0x73, 0x5f, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x65, 0x78, 0x74, 0x72, 0x61, 0x42,
+	0x69, 0x74, 0x73, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6c, 0x65,
+	0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x65, 0x6c, 0x65, 0x6d, 0x73, 0x22,
+	0x83, 0x01, 0x0a, 0x03, 0x46, 0x65, 0x65, 0x12, 0x2f, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73,
+	0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x43, 0x6f, 0x69, 0x6e,
+	0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x61, 0x73, 0x5f,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x67, 0x61, 0x73,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x79, 0x65, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x61, 0x79, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x67,
+	0x72, 0x61, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x72,
+	0x61, 0x6e, 0x74, 0x65, 0x72, 0x22, 0x34, 0x0a, 0x04, 0x43, 0x6f, 0x69, 0x6e, 0x12, 0x14, 0x0a,
+	0x05, 0x64, 0x65, 0x6e, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x64, 0x65,
+	0x6e, 0x6f, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x5e, 0x0a, 0x0f, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x35,
+	0x0a, 0x07, 0x70, 0x75, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x2e, 0x76, 0x32, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x52, 0x06, 0x70,
+	0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x22, 0x4e, 0x0a, 0x09, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x07, 0x65, 0x64, 0x32, 0x35,
+	0x35, 0x31, 0x39, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x07, 0x65, 0x64, 0x32,
+	0x35, 0x35, 0x31, 0x39, 0x12, 0x1e, 0x0a, 0x09, 0x73, 0x65, 0x63, 0x70, 0x32, 0x35, 0x36, 0x6b,
+	0x31, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x09, 0x73, 0x65, 0x63, 0x70, 0x32,
+	0x35, 0x36, 0x6b, 0x31, 0x42, 0x05, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x22, 0xb7, 0x02, 0x0a, 0x0f,
+	0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12,
+	0x34, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e,
+	0x76, 0x32, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x05,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x3d, 0x0a, 0x08, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73,
+	0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x45, 0x76, 0x69, 0x64,
+	0x65, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x08, 0x65, 0x76, 0x69, 0x64,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73,
+	0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x09, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x3a, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73,
+	0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x04, 0x61, 0x62, 0x63, 0x69, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x2e, 0x76, 0x32, 0x2e, 0x41, 0x42, 0x43, 0x49, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52,
+	0x04, 0x61, 0x62, 0x63, 0x69, 0x22, 0x49, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x79, 0x74, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x42, 0x79, 0x74, 0x65,
+	0x73, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f, 0x67, 0x61, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x47, 0x61, 0x73, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04,
+	0x22, 0x9f, 0x01, 0x0a, 0x0e, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x12, 0x2b, 0x0a, 0x12, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x67, 0x65, 0x5f, 0x6e,
+	0x75, 0x6d, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0f, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73,
+	0x12, 0x43, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x22, 0x35, 0x0a, 0x0f, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x50,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x70, 0x75, 0x62, 0x5f, 0x6b, 0x65, 0x79,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x75,
+	0x62, 0x4b, 0x65, 0x79, 0x54, 0x79, 0x70, 0x65, 0x73, 0x22, 0x21, 0x0a, 0x0d, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x70,
+	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x61, 0x70, 0x70, 0x22, 0x4f, 0x0a, 0x0a,
+	0x41, 0x42, 0x43, 0x49, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x41, 0x0a, 0x1d, 0x76, 0x6f,
+	0x74, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x1a, 0x76, 0x6f, 0x74, 0x65, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x5a, 0x0a,
+	0x0c, 0x48, 0x61, 0x73, 0x68, 0x65, 0x64, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x26, 0x0a,
+	0x0f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4d, 0x61, 0x78,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6d,
+	0x61, 0x78, 0x5f, 0x67, 0x61, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x4d, 0x61, 0x78, 0x47, 0x61, 0x73, 0x2a, 0x73, 0x0a, 0x0b, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x49, 0x44, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x19, 0x0a, 0x15, 0x42, 0x4c, 0x4f, 0x43,
+	0x4b, 0x5f, 0x49, 0x44, 0x5f, 0x46, 0x4c, 0x41, 0x47, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
+	0x4e, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x5f, 0x49, 0x44, 0x5f,
+	0x46, 0x4c, 0x41, 0x47, 0x5f, 0x41, 0x42, 0x53, 0x45, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x18, 0x0a,
+	0x14, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x5f, 0x49, 0x44, 0x5f, 0x46, 0x4c, 0x41, 0x47, 0x5f, 0x43,
+	0x4f, 0x4d, 0x4d, 0x49, 0x54, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x42, 0x4c, 0x4f, 0x43, 0x4b,
+	0x5f, 0x49, 0x44, 0x5f, 0x46, 0x4c, 0x41, 0x47, 0x5f, 0x4e, 0x49, 0x4c, 0x10, 0x03, 0x2a, 0x4b,
+	0x0a, 0x0f, 0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x12,
+	0x0a, 0x0e, 0x44, 0x55, 0x50, 0x4c, 0x49, 0x43, 0x41, 0x54, 0x45, 0x5f, 0x56, 0x4f, 0x54, 0x45,
+	0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x4c, 0x49, 0x47, 0x48, 0x54, 0x5f, 0x43, 0x4c, 0x49, 0x45,
+	0x4e, 0x54, 0x5f, 0x41, 0x54, 0x54, 0x41, 0x43, 0x4b, 0x10, 0x02, 0x42, 0x4e, 0x5a, 0x4c, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x69, 0x6e, 0x67, 0x66, 0x61, 0x73, 0x74, 0x2f, 0x66, 0x69, 0x72, 0x65, 0x68, 0x6f, 0x73, 0x65,
+	0x2d, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2f, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2f, 0x70,
+	0x62, 0x2f, 0x73, 0x66, 0x2f, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2f, 0x74, 0x79, 0x70, 0x65,
+	0x2f, 0x76, 0x32, 0x3b, 0x70, 0x62, 0x63, 0x6f, 0x6d, 0x6f, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1701,62 +3171,109 @@ func file_sf_cosmos_type_v2_block_proto_rawDescGZIP() []byte {
 	return file_sf_cosmos_type_v2_block_proto_rawDescData
 }
 
-var file_sf_cosmos_type_v2_block_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_sf_cosmos_type_v2_block_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_sf_cosmos_type_v2_block_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_sf_cosmos_type_v2_block_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_sf_cosmos_type_v2_block_proto_goTypes = []interface{}{
-	(MisbehaviorType)(0),          // 0: sf.cosmos.type.v2.MisbehaviorType
-	(*Block)(nil),                 // 1: sf.cosmos.type.v2.Block
-	(*Header)(nil),                // 2: sf.cosmos.type.v2.Header
-	(*Consensus)(nil),             // 3: sf.cosmos.type.v2.Consensus
-	(*BlockID)(nil),               // 4: sf.cosmos.type.v2.BlockID
-	(*PartSetHeader)(nil),         // 5: sf.cosmos.type.v2.PartSetHeader
-	(*Misbehavior)(nil),           // 6: sf.cosmos.type.v2.Misbehavior
-	(*Validator)(nil),             // 7: sf.cosmos.type.v2.Validator
-	(*Event)(nil),                 // 8: sf.cosmos.type.v2.Event
-	(*EventAttribute)(nil),        // 9: sf.cosmos.type.v2.EventAttribute
-	(*EventBytes)(nil),            // 10: sf.cosmos.type.v2.EventBytes
-	(*EventAttributeBytes)(nil),   // 11: sf.cosmos.type.v2.EventAttributeBytes
-	(*TxResults)(nil),             // 12: sf.cosmos.type.v2.TxResults
-	(*ValidatorUpdate)(nil),       // 13: sf.cosmos.type.v2.ValidatorUpdate
-	(*PublicKey)(nil),             // 14: sf.cosmos.type.v2.PublicKey
-	(*ConsensusParams)(nil),       // 15: sf.cosmos.type.v2.ConsensusParams
-	(*BlockParams)(nil),           // 16: sf.cosmos.type.v2.BlockParams
-	(*EvidenceParams)(nil),        // 17: sf.cosmos.type.v2.EvidenceParams
-	(*ValidatorParams)(nil),       // 18: sf.cosmos.type.v2.ValidatorParams
-	(*VersionParams)(nil),         // 19: sf.cosmos.type.v2.VersionParams
-	(*HashedParams)(nil),          // 20: sf.cosmos.type.v2.HashedParams
-	(*timestamppb.Timestamp)(nil), // 21: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),   // 22: google.protobuf.Duration
+	(BlockIDFlag)(0),              // 0: sf.cosmos.type.v2.BlockIDFlag
+	(MisbehaviorType)(0),          // 1: sf.cosmos.type.v2.MisbehaviorType
+	(*Block)(nil),                 // 2: sf.cosmos.type.v2.Block
+	(*Header)(nil),                // 3: sf.cosmos.type.v2.Header
+	(*Consensus)(nil),             // 4: sf.cosmos.type.v2.Consensus
+	(*BlockID)(nil),               // 5: sf.cosmos.type.v2.BlockID
+	(*PartSetHeader)(nil),         // 6: sf.cosmos.type.v2.PartSetHeader
+	(*Commit)(nil),                // 7: sf.cosmos.type.v2.Commit
+	(*CommitSig)(nil),             // 8: sf.cosmos.type.v2.CommitSig
+	(*ExtendedCommitInfo)(nil),    // 9: sf.cosmos.type.v2.ExtendedCommitInfo
+	(*ExtendedVoteInfo)(nil),      // 10: sf.cosmos.type.v2.ExtendedVoteInfo
+	(*Misbehavior)(nil),           // 11: sf.cosmos.type.v2.Misbehavior
+	(*Validator)(nil),             // 12: sf.cosmos.type.v2.Validator
+	(*ValidatorSet)(nil),          // 13: sf.cosmos.type.v2.ValidatorSet
+	(*ValidatorInfo)(nil),         // 14: sf.cosmos.type.v2.ValidatorInfo
+	(*Event)(nil),                 // 15: sf.cosmos.type.v2.Event
+	(*EventAttribute)(nil),        // 16: sf.cosmos.type.v2.EventAttribute
+	(*TxResults)(nil),             // 17: sf.cosmos.type.v2.TxResults
+	(*Tx)(nil),                    // 18: sf.cosmos.type.v2.Tx
+	(*TxRaw)(nil),                 // 19: sf.cosmos.type.v2.TxRaw
+	(*TxBody)(nil),                // 20: sf.cosmos.type.v2.TxBody
+	(*AuthInfo)(nil),              // 21: sf.cosmos.type.v2.AuthInfo
+	(*SignerInfo)(nil),            // 22: sf.cosmos.type.v2.SignerInfo
+	(*ModeInfo)(nil),              // 23: sf.cosmos.type.v2.ModeInfo
+	(*CompactBitArray)(nil),       // 24: sf.cosmos.type.v2.CompactBitArray
+	(*Fee)(nil),                   // 25: sf.cosmos.type.v2.Fee
+	(*Coin)(nil),                  // 26: sf.cosmos.type.v2.Coin
+	(*ValidatorUpdate)(nil),       // 27: sf.cosmos.type.v2.ValidatorUpdate
+	(*PublicKey)(nil),             // 28: sf.cosmos.type.v2.PublicKey
+	(*ConsensusParams)(nil),       // 29: sf.cosmos.type.v2.ConsensusParams
+	(*BlockParams)(nil),           // 30: sf.cosmos.type.v2.BlockParams
+	(*EvidenceParams)(nil),        // 31: sf.cosmos.type.v2.EvidenceParams
+	(*ValidatorParams)(nil),       // 32: sf.cosmos.type.v2.ValidatorParams
+	(*VersionParams)(nil),         // 33: sf.cosmos.type.v2.VersionParams
+	(*ABCIParams)(nil),            // 34: sf.cosmos.type.v2.ABCIParams
+	(*HashedParams)(nil),          // 35: sf.cosmos.type.v2.HashedParams
+	(*ModeInfo_Single)(nil),       // 36: sf.cosmos.type.v2.ModeInfo.Single
+	(*ModeInfo_Multi)(nil),        // 37: sf.cosmos.type.v2.ModeInfo.Multi
+	(*timestamppb.Timestamp)(nil), // 38: google.protobuf.Timestamp
+	(*anypb.Any)(nil),             // 39: google.protobuf.Any
+	(*durationpb.Duration)(nil),   // 40: google.protobuf.Duration
 }
 var file_sf_cosmos_type_v2_block_proto_depIdxs = []int32{
-	21, // 0: sf.cosmos.type.v2.Block.time:type_name -> google.protobuf.Timestamp
-	2,  // 1: sf.cosmos.type.v2.Block.header:type_name -> sf.cosmos.type.v2.Header
-	6,  // 2: sf.cosmos.type.v2.Block.misbehavior:type_name -> sf.cosmos.type.v2.Misbehavior
-	8,  // 3: sf.cosmos.type.v2.Block.events:type_name -> sf.cosmos.type.v2.Event
-	12, // 4: sf.cosmos.type.v2.Block.tx_results:type_name -> sf.cosmos.type.v2.TxResults
-	13, // 5: sf.cosmos.type.v2.Block.validator_updates:type_name -> sf.cosmos.type.v2.ValidatorUpdate
-	15, // 6: sf.cosmos.type.v2.Block.consensus_param_updates:type_name -> sf.cosmos.type.v2.ConsensusParams
-	3,  // 7: sf.cosmos.type.v2.Header.version:type_name -> sf.cosmos.type.v2.Consensus
-	21, // 8: sf.cosmos.type.v2.Header.time:type_name -> google.protobuf.Timestamp
-	4,  // 9: sf.cosmos.type.v2.Header.last_block_id:type_name -> sf.cosmos.type.v2.BlockID
-	5,  // 10: sf.cosmos.type.v2.BlockID.part_set_header:type_name -> sf.cosmos.type.v2.PartSetHeader
-	0,  // 11: sf.cosmos.type.v2.Misbehavior.type:type_name -> sf.cosmos.type.v2.MisbehaviorType
-	7,  // 12: sf.cosmos.type.v2.Misbehavior.validator:type_name -> sf.cosmos.type.v2.Validator
-	21, // 13: sf.cosmos.type.v2.Misbehavior.time:type_name -> google.protobuf.Timestamp
-	9,  // 14: sf.cosmos.type.v2.Event.attributes:type_name -> sf.cosmos.type.v2.EventAttribute
-	11, // 15: sf.cosmos.type.v2.EventBytes.attributes:type_name -> sf.cosmos.type.v2.EventAttributeBytes
-	8,  // 16: sf.cosmos.type.v2.TxResults.events:type_name -> sf.cosmos.type.v2.Event
-	14, // 17: sf.cosmos.type.v2.ValidatorUpdate.pub_key:type_name -> sf.cosmos.type.v2.PublicKey
-	16, // 18: sf.cosmos.type.v2.ConsensusParams.block:type_name -> sf.cosmos.type.v2.BlockParams
-	17, // 19: sf.cosmos.type.v2.ConsensusParams.evidence:type_name -> sf.cosmos.type.v2.EvidenceParams
-	18, // 20: sf.cosmos.type.v2.ConsensusParams.validator:type_name -> sf.cosmos.type.v2.ValidatorParams
-	19, // 21: sf.cosmos.type.v2.ConsensusParams.version:type_name -> sf.cosmos.type.v2.VersionParams
-	22, // 22: sf.cosmos.type.v2.EvidenceParams.max_age_duration:type_name -> google.protobuf.Duration
-	23, // [23:23] is the sub-list for method output_type
-	23, // [23:23] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	38, // 0: sf.cosmos.type.v2.Block.time:type_name -> google.protobuf.Timestamp
+	3,  // 1: sf.cosmos.type.v2.Block.header:type_name -> sf.cosmos.type.v2.Header
+	11, // 2: sf.cosmos.type.v2.Block.misbehavior:type_name -> sf.cosmos.type.v2.Misbehavior
+	15, // 3: sf.cosmos.type.v2.Block.events:type_name -> sf.cosmos.type.v2.Event
+	17, // 4: sf.cosmos.type.v2.Block.tx_results:type_name -> sf.cosmos.type.v2.TxResults
+	27, // 5: sf.cosmos.type.v2.Block.validator_updates:type_name -> sf.cosmos.type.v2.ValidatorUpdate
+	29, // 6: sf.cosmos.type.v2.Block.consensus_param_updates:type_name -> sf.cosmos.type.v2.ConsensusParams
+	7,  // 7: sf.cosmos.type.v2.Block.last_commit:type_name -> sf.cosmos.type.v2.Commit
+	13, // 8: sf.cosmos.type.v2.Block.validator_set:type_name -> sf.cosmos.type.v2.ValidatorSet
+	9,  // 9: sf.cosmos.type.v2.Block.extended_commit_info:type_name -> sf.cosmos.type.v2.ExtendedCommitInfo
+	15, // 10: sf.cosmos.type.v2.Block.begin_block_events:type_name -> sf.cosmos.type.v2.Event
+	15, // 11: sf.cosmos.type.v2.Block.end_block_events:type_name -> sf.cosmos.type.v2.Event
+	18, // 12: sf.cosmos.type.v2.Block.decoded_txs:type_name -> sf.cosmos.type.v2.Tx
+	4,  // 13: sf.cosmos.type.v2.Header.version:type_name -> sf.cosmos.type.v2.Consensus
+	38, // 14: sf.cosmos.type.v2.Header.time:type_name -> google.protobuf.Timestamp
+	5,  // 15: sf.cosmos.type.v2.Header.last_block_id:type_name -> sf.cosmos.type.v2.BlockID
+	6,  // 16: sf.cosmos.type.v2.BlockID.part_set_header:type_name -> sf.cosmos.type.v2.PartSetHeader
+	5,  // 17: sf.cosmos.type.v2.Commit.block_id:type_name -> sf.cosmos.type.v2.BlockID
+	8,  // 18: sf.cosmos.type.v2.Commit.signatures:type_name -> sf.cosmos.type.v2.CommitSig
+	0,  // 19: sf.cosmos.type.v2.CommitSig.block_id_flag:type_name -> sf.cosmos.type.v2.BlockIDFlag
+	38, // 20: sf.cosmos.type.v2.CommitSig.timestamp:type_name -> google.protobuf.Timestamp
+	10, // 21: sf.cosmos.type.v2.ExtendedCommitInfo.votes:type_name -> sf.cosmos.type.v2.ExtendedVoteInfo
+	12, // 22: sf.cosmos.type.v2.ExtendedVoteInfo.validator:type_name -> sf.cosmos.type.v2.Validator
+	0,  // 23: sf.cosmos.type.v2.ExtendedVoteInfo.block_id_flag:type_name -> sf.cosmos.type.v2.BlockIDFlag
+	1,  // 24: sf.cosmos.type.v2.Misbehavior.type:type_name -> sf.cosmos.type.v2.MisbehaviorType
+	12, // 25: sf.cosmos.type.v2.Misbehavior.validator:type_name -> sf.cosmos.type.v2.Validator
+	38, // 26: sf.cosmos.type.v2.Misbehavior.time:type_name -> google.protobuf.Timestamp
+	14, // 27: sf.cosmos.type.v2.ValidatorSet.validators:type_name -> sf.cosmos.type.v2.ValidatorInfo
+	28, // 28: sf.cosmos.type.v2.ValidatorInfo.pub_key:type_name -> sf.cosmos.type.v2.PublicKey
+	16, // 29: sf.cosmos.type.v2.Event.attributes:type_name -> sf.cosmos.type.v2.EventAttribute
+	15, // 30: sf.cosmos.type.v2.TxResults.events:type_name -> sf.cosmos.type.v2.Event
+	20, // 31: sf.cosmos.type.v2.Tx.body:type_name -> sf.cosmos.type.v2.TxBody
+	21, // 32: sf.cosmos.type.v2.Tx.auth_info:type_name -> sf.cosmos.type.v2.AuthInfo
+	39, // 33: sf.cosmos.type.v2.TxBody.messages:type_name -> google.protobuf.Any
+	39, // 34: sf.cosmos.type.v2.TxBody.extension_options:type_name -> google.protobuf.Any
+	39, // 35: sf.cosmos.type.v2.TxBody.non_critical_extension_options:type_name -> google.protobuf.Any
+	22, // 36: sf.cosmos.type.v2.AuthInfo.signer_infos:type_name -> sf.cosmos.type.v2.SignerInfo
+	25, // 37: sf.cosmos.type.v2.AuthInfo.fee:type_name -> sf.cosmos.type.v2.Fee
+	39, // 38: sf.cosmos.type.v2.SignerInfo.public_key:type_name -> google.protobuf.Any
+	23, // 39: sf.cosmos.type.v2.SignerInfo.mode_info:type_name -> sf.cosmos.type.v2.ModeInfo
+	36, // 40: sf.cosmos.type.v2.ModeInfo.single:type_name -> sf.cosmos.type.v2.ModeInfo.Single
+	37, // 41: sf.cosmos.type.v2.ModeInfo.multi:type_name -> sf.cosmos.type.v2.ModeInfo.Multi
+	26, // 42: sf.cosmos.type.v2.Fee.amount:type_name -> sf.cosmos.type.v2.Coin
+	28, // 43: sf.cosmos.type.v2.ValidatorUpdate.pub_key:type_name -> sf.cosmos.type.v2.PublicKey
+	30, // 44: sf.cosmos.type.v2.ConsensusParams.block:type_name -> sf.cosmos.type.v2.BlockParams
+	31, // 45: sf.cosmos.type.v2.ConsensusParams.evidence:type_name -> sf.cosmos.type.v2.EvidenceParams
+	32, // 46: sf.cosmos.type.v2.ConsensusParams.validator:type_name -> sf.cosmos.type.v2.ValidatorParams
+	33, // 47: sf.cosmos.type.v2.ConsensusParams.version:type_name -> sf.cosmos.type.v2.VersionParams
+	34, // 48: sf.cosmos.type.v2.ConsensusParams.abci:type_name -> sf.cosmos.type.v2.ABCIParams
+	40, // 49: sf.cosmos.type.v2.EvidenceParams.max_age_duration:type_name -> google.protobuf.Duration
+	24, // 50: sf.cosmos.type.v2.ModeInfo.Multi.bitarray:type_name -> sf.cosmos.type.v2.CompactBitArray
+	23, // 51: sf.cosmos.type.v2.ModeInfo.Multi.mode_infos:type_name -> sf.cosmos.type.v2.ModeInfo
+	52, // [52:52] is the sub-list for method output_type
+	52, // [52:52] is the sub-list for method input_type
+	52, // [52:52] is the sub-list for extension type_name
+	52, // [52:52] is the sub-list for extension extendee
+	0,  // [0:52] is the sub-list for field type_name
 }
 
 func init() { file_sf_cosmos_type_v2_block_proto_init() }
@@ -1826,7 +3343,7 @@ func file_sf_cosmos_type_v2_block_proto_init() {
 			}
 		}
 		file_sf_cosmos_type_v2_block_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Misbehavior); i {
+			switch v := v.(*Commit); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1838,7 +3355,7 @@ func file_sf_cosmos_type_v2_block_proto_init() {
 			}
 		}
 		file_sf_cosmos_type_v2_block_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Validator); i {
+			switch v := v.(*CommitSig); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1850,7 +3367,7 @@ func file_sf_cosmos_type_v2_block_proto_init() {
 			}
 		}
 		file_sf_cosmos_type_v2_block_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event); i {
+			switch v := v.(*ExtendedCommitInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1862,7 +3379,7 @@ func file_sf_cosmos_type_v2_block_proto_init() {
 			}
 		}
 		file_sf_cosmos_type_v2_block_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EventAttribute); i {
+			switch v := v.(*ExtendedVoteInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1874,7 +3391,7 @@ func file_sf_cosmos_type_v2_block_proto_init() {
 			}
 		}
 		file_sf_cosmos_type_v2_block_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EventBytes); i {
+			switch v := v.(*Misbehavior); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1886,7 +3403,7 @@ func file_sf_cosmos_type_v2_block_proto_init() {
 			}
 		}
 		file_sf_cosmos_type_v2_block_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EventAttributeBytes); i {
+			switch v := v.(*Validator); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1898,7 +3415,7 @@ func file_sf_cosmos_type_v2_block_proto_init() {
 			}
 		}
 		file_sf_cosmos_type_v2_block_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TxResults); i {
+			switch v := v.(*ValidatorSet); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1910,7 +3427,7 @@ func file_sf_cosmos_type_v2_block_proto_init() {
 			}
 		}
 		file_sf_cosmos_type_v2_block_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ValidatorUpdate); i {
+			switch v := v.(*ValidatorInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1922,7 +3439,7 @@ func file_sf_cosmos_type_v2_block_proto_init() {
 			}
 		}
 		file_sf_cosmos_type_v2_block_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PublicKey); i {
+			switch v := v.(*Event); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1934,7 +3451,7 @@ func file_sf_cosmos_type_v2_block_proto_init() {
 			}
 		}
 		file_sf_cosmos_type_v2_block_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConsensusParams); i {
+			switch v := v.(*EventAttribute); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1946,7 +3463,7 @@ func file_sf_cosmos_type_v2_block_proto_init() {
 			}
 		}
 		file_sf_cosmos_type_v2_block_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlockParams); i {
+			switch v := v.(*TxResults); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1958,7 +3475,7 @@ func file_sf_cosmos_type_v2_block_proto_init() {
 			}
 		}
 		file_sf_cosmos_type_v2_block_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EvidenceParams); i {
+			switch v := v.(*Tx); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1970,7 +3487,7 @@ func file_sf_cosmos_type_v2_block_proto_init() {
 			}
 		}
 		file_sf_cosmos_type_v2_block_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ValidatorParams); i {
+			switch v := v.(*TxRaw); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1982,7 +3499,7 @@ func file_sf_cosmos_type_v2_block_proto_init() {
 			}
 		}
 		file_sf_cosmos_type_v2_block_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VersionParams); i {
+			switch v := v.(*TxBody); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1994,6 +3511,174 @@ func file_sf_cosmos_type_v2_block_proto_init() {
 			}
 		}
 		file_sf_cosmos_type_v2_block_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_cosmos_type_v2_block_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignerInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_cosmos_type_v2_block_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModeInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_cosmos_type_v2_block_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompactBitArray); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_cosmos_type_v2_block_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Fee); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_cosmos_type_v2_block_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Coin); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_cosmos_type_v2_block_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidatorUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_cosmos_type_v2_block_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PublicKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_cosmos_type_v2_block_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConsensusParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_cosmos_type_v2_block_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_cosmos_type_v2_block_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EvidenceParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_cosmos_type_v2_block_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidatorParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_cosmos_type_v2_block_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VersionParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_cosmos_type_v2_block_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ABCIParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_cosmos_type_v2_block_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*HashedParams); i {
 			case 0:
 				return &v.state
@@ -2005,8 +3690,36 @@ func file_sf_cosmos_type_v2_block_proto_init() {
 				return nil
 			}
 		}
+		file_sf_cosmos_type_v2_block_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModeInfo_Single); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_cosmos_type_v2_block_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModeInfo_Multi); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_sf_cosmos_type_v2_block_proto_msgTypes[21].OneofWrappers = []interface{}{
+		(*ModeInfo_Single_)(nil),
+		(*ModeInfo_Multi_)(nil),
 	}
-	file_sf_cosmos_type_v2_block_proto_msgTypes[13].OneofWrappers = []interface{}{
+	file_sf_cosmos_type_v2_block_proto_msgTypes[26].OneofWrappers = []interface{}{
 		(*PublicKey_Ed25519)(nil),
 		(*PublicKey_Secp256K1)(nil),
 	}
@@ -2015,8 +3728,8 @@ func file_sf_cosmos_type_v2_block_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_sf_cosmos_type_v2_block_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   20,
+			NumEnums:      2,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -21,6 +21,7 @@ func init() {
 	logging.InstantiateLoggers(logging.WithDefaultLevel(zap.InfoLevel))
 
 	rootCmd.AddCommand(newFetchCmd(logger, tracer))
+	rootCmd.AddCommand(newToolsCmd(logger, tracer))
 	rootCmd.AddCommand(NewToolsFixUnknownTypeBlocks(logger, tracer))
 	rootCmd.AddCommand(NewToolsFixBlockHashes(logger, tracer))
 }
@@ -41,3 +42,12 @@ func newFetchCmd(logger *zap.Logger, tracer logging.Tracer) *cobra.Command {
 	cmd.AddCommand(v03811.NewFetchCmd(logger, tracer))
 	return cmd
 }
+
+func newToolsCmd(logger *zap.Logger, tracer logging.Tracer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tools",
+		Short: "tooling to work with blocks and chain storage",
+	}
+	cmd.AddCommand(v03811.NewToolsExtractFromChainStorageCmd(logger, tracer))
+	return cmd
+}
@@ -21,6 +21,7 @@ func init() {
 	logging.InstantiateLoggers(logging.WithDefaultLevel(zap.InfoLevel))
 
 	rootCmd.AddCommand(newFetchCmd(logger, tracer))
+	rootCmd.AddCommand(newToolsCmd(logger, tracer))
 }
 
 func main() {
@@ -39,3 +40,12 @@ func newFetchCmd(logger *zap.Logger, tracer logging.Tracer) *cobra.Command {
 	cmd.AddCommand(v03807.NewFetchCmd(logger, tracer))
 	return cmd
 }
+
+func newToolsCmd(logger *zap.Logger, tracer logging.Tracer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tools",
+		Short: "tooling to work with blocks and chain storage",
+	}
+	cmd.AddCommand(v03807.NewToolsExtractFromChainStorageCmd(logger, tracer))
+	return cmd
+}